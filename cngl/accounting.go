@@ -0,0 +1,242 @@
+package cngl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-api-go/v2/accounting"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.uber.org/zap"
+)
+
+// ledger of the balances of NeoFS users.
+type balances struct {
+	log *zap.Logger
+
+	// precision of all balances, 8 or 12
+	precision uint32
+
+	// balance of the owners missing in the ledger
+	defaultValue int64
+
+	// path to the JSON file to persist the ledger in, not persisted if empty
+	filepath string
+
+	mtx sync.RWMutex
+	// balances by owner IDs
+	m map[string]int64
+	// balances set in the config, restored on reset
+	initial map[string]int64
+}
+
+var errInsufficientFunds = errors.New("insufficient funds")
+
+// precisions of the balances supported by the accounting service.
+const (
+	balancePrecisionGAS     = 8
+	balancePrecisionBalance = 12
+)
+
+func (x *balances) init() {
+	x.m = make(map[string]int64)
+}
+
+// returns current balance of the owner.
+func (x *balances) get(id *owner.ID) int64 {
+	x.mtx.RLock()
+	defer x.mtx.RUnlock()
+
+	if v, ok := x.m[id.String()]; ok {
+		return v
+	}
+
+	return x.defaultValue
+}
+
+// overwrites current balance of the owner.
+func (x *balances) set(id *owner.ID, v int64) {
+	x.mtx.Lock()
+	x.m[id.String()] = v
+	x.mtx.Unlock()
+}
+
+// converts amount of GAS in Fixed8 to the ledger precision.
+func (x *balances) fromFixed8(v uint64) int64 {
+	if x.precision == balancePrecisionBalance {
+		v *= 10_000
+	}
+
+	return int64(v)
+}
+
+// must be called under lock.
+func (x *balances) value(key string) int64 {
+	if v, ok := x.m[key]; ok {
+		return v
+	}
+
+	return x.defaultValue
+}
+
+// increases balance of the owner by the amount and returns the resulting balance.
+func (x *balances) deposit(id *owner.ID, amount int64) int64 {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	key := id.String()
+
+	x.m[key] = x.value(key) + amount
+
+	x.save()
+
+	return x.m[key]
+}
+
+// decreases balance of the owner by the amount and returns the resulting balance.
+// Returns errInsufficientFunds if balance is less than the amount.
+func (x *balances) withdraw(id *owner.ID, amount int64) (int64, error) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	key := id.String()
+
+	v := x.value(key)
+	if v < amount {
+		return v, errInsufficientFunds
+	}
+
+	x.m[key] = v - amount
+
+	x.save()
+
+	return x.m[key], nil
+}
+
+// moves the amount from one owner to another.
+// Returns errInsufficientFunds if sender balance is less than the amount.
+func (x *balances) transfer(from, to *owner.ID, amount int64) error {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	keyFrom, keyTo := from.String(), to.String()
+
+	v := x.value(keyFrom)
+	if v < amount {
+		return errInsufficientFunds
+	}
+
+	x.m[keyFrom] = v - amount
+	x.m[keyTo] = x.value(keyTo) + amount
+
+	x.save()
+
+	return nil
+}
+
+// writes the ledger to the file if it is configured. Must be called under lock.
+func (x *balances) save() {
+	if x.filepath == "" {
+		return
+	}
+
+	data, err := json.Marshal(x.m)
+	if err != nil {
+		x.log.Error("encode balance ledger", zap.Error(err))
+		return
+	}
+
+	err = os.WriteFile(x.filepath, data, 0600)
+	if err != nil {
+		x.log.Error("write balance ledger file", zap.String("path", x.filepath), zap.Error(err))
+	}
+}
+
+// remembers current balances as initial ones.
+func (x *balances) snapshot() {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.initial = make(map[string]int64, len(x.m))
+
+	for k, v := range x.m {
+		x.initial[k] = v
+	}
+}
+
+// restores initial balances.
+func (x *balances) reset() {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.m = make(map[string]int64, len(x.initial))
+
+	for k, v := range x.initial {
+		x.m[k] = v
+	}
+
+	x.save()
+}
+
+// reads balances persisted in the file if it is configured and exists.
+// Persisted balances override already set ones.
+func (x *balances) load() error {
+	if x.filepath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(x.filepath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	var m map[string]int64
+
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("decode JSON: %w", err)
+	}
+
+	x.mtx.Lock()
+
+	for k, v := range m {
+		x.m[k] = v
+	}
+
+	x.mtx.Unlock()
+
+	return nil
+}
+
+type serviceServerAccounting struct {
+	balances *balances
+}
+
+func (x *serviceServerAccounting) Balance(_ context.Context, req *accounting.BalanceRequest) (*accounting.BalanceResponse, error) {
+	idV2 := req.GetBody().GetOwnerID()
+	if idV2 == nil {
+		return nil, errors.New("missing owner ID")
+	}
+
+	var bal accounting.Decimal
+
+	bal.SetValue(x.balances.get(owner.NewIDFromV2(idV2)))
+	bal.SetPrecision(x.balances.precision)
+
+	var body accounting.BalanceResponseBody
+
+	body.SetBalance(&bal)
+
+	var resp accounting.BalanceResponse
+
+	resp.SetBody(&body)
+
+	return &resp, nil
+}
@@ -0,0 +1,57 @@
+package cngl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/v2/accounting"
+	"github.com/nspcc-dev/neofs-api-go/v2/refs"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+)
+
+func TestServiceServerAccounting_Balance(t *testing.T) {
+	for _, precision := range []uint32{balancePrecisionGAS, balancePrecisionBalance} {
+		bals := balances{
+			precision:    precision,
+			defaultValue: 7,
+		}
+
+		bals.init()
+
+		known, unknown := ownertest.ID(), ownertest.ID()
+
+		bals.set(known, 100)
+
+		x := serviceServerAccounting{balances: &bals}
+
+		for _, tc := range []struct {
+			body *accounting.BalanceRequestBody
+			exp  int64
+		}{
+			{body: balanceRequestBody(known.ToV2()), exp: 100},
+			{body: balanceRequestBody(unknown.ToV2()), exp: 7},
+		} {
+			var req accounting.BalanceRequest
+			req.SetBody(tc.body)
+
+			resp, err := x.Balance(context.Background(), &req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bal := resp.GetBody().GetBalance()
+
+			if bal.GetValue() != tc.exp || bal.GetPrecision() != precision {
+				t.Fatalf("unexpected balance %d with precision %d, expected %d with precision %d",
+					bal.GetValue(), bal.GetPrecision(), tc.exp, precision)
+			}
+		}
+	}
+}
+
+func balanceRequestBody(id *refs.OwnerID) *accounting.BalanceRequestBody {
+	var body accounting.BalanceRequestBody
+	body.SetOwnerID(id)
+
+	return &body
+}
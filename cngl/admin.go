@@ -0,0 +1,735 @@
+package cngl
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/object/address"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.uber.org/zap"
+)
+
+// administrative HTTP API used to inspect and control the application state.
+type adminServer struct {
+	log *zap.Logger
+
+	containers *containers
+
+	netMap *netMap
+
+	balances *balances
+	// fee charged for each withdrawal in the ledger units
+	withdrawFee int64
+
+	sessions *sessions
+
+	faults *faultInjector
+
+	// reapplied after wipe
+	fixtures *fixtures
+
+	localObjects *engine.StorageEngine
+
+	gcEvents *gcEvents
+
+	// directory of the local object storage
+	storagePath string
+}
+
+func (x *adminServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/epoch", x.handleEpoch)
+	mux.HandleFunc("/epoch/tick", x.handleEpochTick)
+	mux.HandleFunc("/eacl", x.handleEACL)
+	mux.HandleFunc("/eacl/history", x.handleEACLHistory)
+	mux.HandleFunc("/eacls", x.handleEACLs)
+	mux.HandleFunc("/netmap/node/state", x.handleNodeState)
+	mux.HandleFunc("/balance", x.handleBalance)
+	mux.HandleFunc("/balance/deposit", x.handleDeposit)
+	mux.HandleFunc("/balance/withdraw", x.handleWithdraw)
+	mux.HandleFunc("/sessions", x.handleSessions)
+	mux.HandleFunc("/faults", x.handleFaults)
+	mux.HandleFunc("/containers", x.handleContainers)
+	mux.HandleFunc("/objects", x.handleObjects)
+	mux.HandleFunc("/object/header", x.handleObjectHeader)
+	mux.HandleFunc("/wipe", x.handleWipe)
+
+	return mux
+}
+
+// writes JSON representation of v as a response.
+func (x *adminServer) writeResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		x.log.Error("write admin response", zap.Error(err))
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, code int, err error) {
+	http.Error(w, err.Error(), code)
+}
+
+// reads container ID from the required "container" query parameter.
+func adminContainerID(r *http.Request) (*cid.ID, error) {
+	s := r.URL.Query().Get("container")
+	if s == "" {
+		return nil, errors.New("missing container ID")
+	}
+
+	id := cid.New()
+
+	err := id.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// reads epoch from the optional "epoch" query parameter, defaults to current epoch.
+func (x *adminServer) epochParam(r *http.Request) (uint64, error) {
+	s := r.URL.Query().Get("epoch")
+	if s == "" {
+		return x.netMap.CurrentEpoch(), nil
+	}
+
+	epoch, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch: %w", err)
+	}
+
+	return epoch, nil
+}
+
+type adminEpoch struct {
+	Epoch uint64 `json:"epoch"`
+}
+
+// GET /epoch returns current epoch.
+func (x *adminServer) handleEpoch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	x.writeResponse(w, adminEpoch{
+		Epoch: x.netMap.CurrentEpoch(),
+	})
+}
+
+// limit of epochs advanced by single /epoch/tick request, all epoch handlers
+// are called for each epoch under the lock.
+const maxAdminEpochTicks = 1000
+
+// POST /epoch/tick[?n=<N>] advances current epoch by N (1 by default, up to
+// maxAdminEpochTicks) and returns the resulting epoch.
+func (x *adminServer) handleEpochTick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("POST method expected"))
+		return
+	}
+
+	n := uint64(1)
+
+	if s := r.URL.Query().Get("n"); s != "" {
+		var err error
+
+		n, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid number of epochs: %w", err))
+			return
+		}
+
+		if n > maxAdminEpochTicks {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("number of epochs %d exceeds limit %d", n, maxAdminEpochTicks))
+			return
+		}
+	}
+
+	x.writeResponse(w, adminEpoch{
+		Epoch: x.netMap.tickEpochs(n),
+	})
+}
+
+// eACL table with the epoch in which it was set.
+type adminEACLRecord struct {
+	Epoch uint64 `json:"epoch"`
+
+	Table json.RawMessage `json:"table"`
+
+	Signature json.RawMessage `json:"signature,omitempty"`
+
+	SessionToken json.RawMessage `json:"sessionToken,omitempty"`
+}
+
+func (x *adminEACLRecord) fromRecord(r eACLRecord) error {
+	var err error
+
+	x.Epoch = r.epoch
+
+	x.Table, err = r.table.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("encode eACL table: %w", err)
+	}
+
+	if sig := r.table.Signature(); sig != nil {
+		x.Signature, err = sig.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("encode eACL signature: %w", err)
+		}
+	}
+
+	if tok := r.table.SessionToken(); tok != nil {
+		x.SessionToken, err = tok.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("encode eACL session token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GET /eacl?container=<ID>[&epoch=<N>] returns eACL table which was
+// in force at the given epoch.
+func (x *adminServer) handleEACL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	id, err := adminContainerID(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	epoch, err := x.epochParam(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rec, ok := x.containers.eACLAtEpoch(id, epoch)
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("eACL of the container %s not found at epoch %d", id, epoch))
+		return
+	}
+
+	var res adminEACLRecord
+
+	err = res.fromRecord(rec)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	x.writeResponse(w, res)
+}
+
+// current eACL table of the container.
+type adminContainerEACL struct {
+	Container string `json:"container"`
+
+	adminEACLRecord
+}
+
+// GET /eacls returns current eACL tables of all containers.
+func (x *adminServer) handleEACLs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	recs := x.containers.currentEACLs()
+
+	res := make([]adminContainerEACL, len(recs))
+
+	for i := range recs {
+		res[i].Container = recs[i].table.CID().String()
+
+		err := res[i].fromRecord(recs[i])
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	x.writeResponse(w, res)
+}
+
+// GET /eacl/history?container=<ID> returns all eACL tables of the container
+// ordered by setting time.
+func (x *adminServer) handleEACLHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	id, err := adminContainerID(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	history := x.containers.eACLHistory(id)
+
+	res := make([]adminEACLRecord, len(history))
+
+	for i := range history {
+		err = res[i].fromRecord(history[i])
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	x.writeResponse(w, res)
+}
+
+// POST /netmap/node/state?key=<HEX>&state=<online|offline>[&epoch=<N>] schedules
+// state change of the node at the given epoch (next one by default).
+func (x *adminServer) handleNodeState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("POST method expected"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	key, err := hex.DecodeString(query.Get("key"))
+	if err != nil || len(key) == 0 {
+		writeAdminError(w, http.StatusBadRequest, errors.New("missing or invalid hex-encoded node key"))
+		return
+	}
+
+	state, err := parseNodeState(query.Get("state"))
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	epoch := x.netMap.CurrentEpoch() + 1
+
+	if s := query.Get("epoch"); s != "" {
+		epoch, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid epoch: %w", err))
+			return
+		}
+	}
+
+	err = x.netMap.scheduleTransition(epoch, key, state)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	x.writeResponse(w, adminEpoch{
+		Epoch: epoch,
+	})
+}
+
+// reads owner ID from the required "owner" query parameter.
+func adminOwnerID(r *http.Request) (*owner.ID, error) {
+	s := r.URL.Query().Get("owner")
+	if s == "" {
+		return nil, errors.New("missing owner ID")
+	}
+
+	id := owner.NewID()
+
+	err := id.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// reads positive amount from the required "amount" query parameter.
+func adminAmount(r *http.Request) (int64, error) {
+	amount, err := strconv.ParseInt(r.URL.Query().Get("amount"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	if amount <= 0 {
+		return 0, errors.New("amount must be positive")
+	}
+
+	return amount, nil
+}
+
+type adminBalance struct {
+	Owner string `json:"owner"`
+
+	Value int64 `json:"value"`
+
+	Precision uint32 `json:"precision"`
+}
+
+func (x *adminServer) writeBalance(w http.ResponseWriter, id *owner.ID, v int64) {
+	x.writeResponse(w, adminBalance{
+		Owner:     id.String(),
+		Value:     v,
+		Precision: x.balances.precision,
+	})
+}
+
+// GET /balance?owner=<ID> returns current balance of the owner.
+func (x *adminServer) handleBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	id, err := adminOwnerID(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	x.writeBalance(w, id, x.balances.get(id))
+}
+
+// POST /balance/deposit?owner=<ID>&amount=<N> increases balance of the owner
+// and returns the resulting balance.
+func (x *adminServer) handleDeposit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("POST method expected"))
+		return
+	}
+
+	id, err := adminOwnerID(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	amount, err := adminAmount(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	x.writeBalance(w, id, x.balances.deposit(id, amount))
+}
+
+// POST /balance/withdraw?owner=<ID>&amount=<N> decreases balance of the owner
+// by the amount plus withdrawal fee and returns the resulting balance.
+func (x *adminServer) handleWithdraw(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("POST method expected"))
+		return
+	}
+
+	id, err := adminOwnerID(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	amount, err := adminAmount(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	v, err := x.balances.withdraw(id, amount+x.withdrawFee)
+	if err != nil {
+		writeAdminError(w, http.StatusConflict, fmt.Errorf("withdraw %d with fee %d: %w", amount, x.withdrawFee, err))
+		return
+	}
+
+	x.writeBalance(w, id, v)
+}
+
+type adminSession struct {
+	Owner string `json:"owner"`
+
+	ID string `json:"id"`
+
+	SessionKey string `json:"sessionKey"`
+
+	Expiration uint64 `json:"expiration"`
+}
+
+// GET /sessions[?owner=<ID>] returns active sessions of the owner or all of them.
+func (x *adminServer) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	var (
+		id  *owner.ID
+		err error
+	)
+
+	if r.URL.Query().Get("owner") != "" {
+		id, err = adminOwnerID(r)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	list := x.sessions.list(id)
+
+	res := make([]adminSession, len(list))
+
+	for i := range list {
+		res[i] = adminSession{
+			Owner:      list[i].owner.String(),
+			ID:         sessionIDString(list[i].tokenID),
+			SessionKey: hex.EncodeToString(list[i].publicKey.Bytes()),
+			Expiration: list[i].exp,
+		}
+	}
+
+	x.writeResponse(w, res)
+}
+
+// returns UUID string of the session token ID, hex if ID is not a valid UUID.
+func sessionIDString(id []byte) string {
+	u, err := uuid.FromBytes(id)
+	if err != nil {
+		return hex.EncodeToString(id)
+	}
+
+	return u.String()
+}
+
+// GET /faults returns current fault rules.
+// POST /faults with JSON rule in the body appends the rule.
+// DELETE /faults[?seed=<N>] removes all rules and reseeds random generator (0 by default).
+func (x *adminServer) handleFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	default:
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET, POST or DELETE method expected"))
+	case http.MethodGet:
+		rules := x.faults.listRules()
+
+		res := make([]cfgFaultRule, len(rules))
+
+		for i := range rules {
+			res[i] = rules[i].toConfig()
+		}
+
+		x.writeResponse(w, res)
+	case http.MethodPost:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("read request body: %w", err))
+			return
+		}
+
+		rule, err := decodeFaultRule(data)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		x.faults.addRule(rule)
+
+		x.writeResponse(w, rule.toConfig())
+	case http.MethodDelete:
+		var seed int64
+
+		if s := r.URL.Query().Get("seed"); s != "" {
+			var err error
+
+			seed, err = strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid seed: %w", err))
+				return
+			}
+		}
+
+		x.faults.reset(seed, nil)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type adminContainer struct {
+	ID string `json:"id"`
+
+	Container json.RawMessage `json:"container"`
+}
+
+// GET /containers[?owner=<ID>] returns containers of the owner or all of them.
+func (x *adminServer) handleContainers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	var (
+		idOwner *owner.ID
+		err     error
+	)
+
+	if r.URL.Query().Get("owner") != "" {
+		idOwner, err = adminOwnerID(r)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	ids, err := x.containers.List(idOwner)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := make([]adminContainer, 0, len(ids))
+
+	for _, id := range ids {
+		cnr, err := x.containers.Get(id)
+		if err != nil {
+			// removed concurrently
+			continue
+		}
+
+		jCnr, err := cnr.MarshalJSON()
+		if err != nil {
+			writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("encode container %s: %w", id, err))
+			return
+		}
+
+		res = append(res, adminContainer{
+			ID:        id.String(),
+			Container: jCnr,
+		})
+	}
+
+	x.writeResponse(w, res)
+}
+
+// GET /objects[?container=<ID>] returns addresses of the objects stored
+// in the container or all local objects.
+func (x *adminServer) handleObjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	var (
+		addrs []*address.Address
+		err   error
+	)
+
+	if r.URL.Query().Get("container") != "" {
+		var id *cid.ID
+
+		id, err = adminContainerID(r)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		addrs, err = engine.Select(x.localObjects, id, object.SearchFilters{})
+	} else {
+		addrs, err = engine.List(x.localObjects, 0)
+	}
+
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("list objects: %w", err))
+		return
+	}
+
+	res := make([]string, len(addrs))
+
+	for i := range addrs {
+		res[i] = addrs[i].String()
+	}
+
+	x.writeResponse(w, res)
+}
+
+// GET /object/header?address=<CID>/<OID> returns header of the local object.
+func (x *adminServer) handleObjectHeader(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("GET method expected"))
+		return
+	}
+
+	addr := address.NewAddress()
+
+	err := addr.Parse(r.URL.Query().Get("address"))
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid object address: %w", err))
+		return
+	}
+
+	obj, err := engine.Head(x.localObjects, addr)
+	if err != nil {
+		writeAdminError(w, http.StatusNotFound, fmt.Errorf("read object header: %w", err))
+		return
+	}
+
+	jObj, err := obj.MarshalJSON()
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("encode object header: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err = w.Write(jObj)
+	if err != nil {
+		x.log.Error("write admin response", zap.Error(err))
+	}
+}
+
+// POST /wipe removes all containers, eACL tables, sessions and objects,
+// and restores initial balances. Pending container changes are canceled,
+// fixtures are applied again. Current epoch, network map history and
+// scheduled node state transitions are kept.
+func (x *adminServer) handleWipe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAdminError(w, http.StatusMethodNotAllowed, errors.New("POST method expected"))
+		return
+	}
+
+	x.containers.reset()
+	x.balances.reset()
+
+	err := x.sessions.reset()
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("wipe sessions: %w", err))
+		return
+	}
+
+	err = wipeLocalObjects(x.localObjects, x.storagePath, x.gcEvents)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, fmt.Errorf("wipe objects: %w", err))
+		return
+	}
+
+	x.fixtures.applyContainers(x.containers)
+
+	err = x.fixtures.storeObjects(x.localObjects)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	x.log.Info("application state is wiped")
+
+	w.WriteHeader(http.StatusNoContent)
+}
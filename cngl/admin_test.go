@@ -0,0 +1,354 @@
+package cngl
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	objectcore "github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/eacl"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+	"github.com/nspcc-dev/neofs-sdk-go/version"
+	"go.uber.org/zap"
+)
+
+// sends request to the admin server and returns the recorded response.
+func adminRequest(srv *adminServer, method, target, body string) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+
+	w := httptest.NewRecorder()
+
+	srv.handler().ServeHTTP(w, httptest.NewRequest(method, target, r))
+
+	return w
+}
+
+func TestAdmin_EACL(t *testing.T) {
+	nm := new(netMap)
+
+	srv := &adminServer{
+		containers: new(containers),
+		netMap:     nm,
+	}
+
+	srv.containers.init()
+	srv.containers.netMap = nm
+
+	id := cidtest.ID()
+
+	for _, epoch := range []uint64{1, 3} {
+		nm.epoch = epoch
+
+		table := eacl.NewTable()
+		table.SetCID(id)
+
+		srv.containers.putEACLNow(table)
+	}
+
+	var rec struct {
+		Epoch uint64 `json:"epoch"`
+	}
+
+	w := adminRequest(srv, http.MethodGet, "/eacl?container="+id.String()+"&epoch=2", "")
+
+	err := json.Unmarshal(w.Body.Bytes(), &rec)
+	if err != nil {
+		t.Fatalf("decode eACL (%d %s): %v", w.Code, w.Body, err)
+	}
+
+	if rec.Epoch != 1 {
+		t.Fatalf("unexpected eACL epoch %d", rec.Epoch)
+	}
+
+	w = adminRequest(srv, http.MethodGet, "/eacl?container="+id.String()+"&epoch=0", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("eACL before the first one is found: %d", w.Code)
+	}
+
+	var history []json.RawMessage
+
+	w = adminRequest(srv, http.MethodGet, "/eacl/history?container="+id.String(), "")
+
+	err = json.Unmarshal(w.Body.Bytes(), &history)
+	if err != nil {
+		t.Fatalf("decode eACL history (%d %s): %v", w.Code, w.Body, err)
+	}
+
+	if len(history) != 2 {
+		t.Fatalf("unexpected eACL history length %d", len(history))
+	}
+}
+
+func TestAdmin_EpochTick(t *testing.T) {
+	nm := &netMap{log: zap.NewNop(), epoch: 10}
+	nm.init(nil)
+
+	srv := &adminServer{
+		netMap: nm,
+	}
+
+	w := adminRequest(srv, http.MethodPost, "/epoch/tick?n=1000000000", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("too many epochs are accepted: %d", w.Code)
+	}
+
+	w = adminRequest(srv, http.MethodGet, "/epoch/tick", "")
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("epoch is ticked by GET request: %d", w.Code)
+	}
+
+	var res struct {
+		Epoch uint64 `json:"epoch"`
+	}
+
+	w = adminRequest(srv, http.MethodPost, "/epoch/tick?n=2", "")
+
+	err := json.Unmarshal(w.Body.Bytes(), &res)
+	if err != nil {
+		t.Fatalf("decode epoch (%d %s): %v", w.Code, w.Body, err)
+	}
+
+	if res.Epoch != 12 || srv.netMap.CurrentEpoch() != 12 {
+		t.Fatalf("unexpected epoch %d", res.Epoch)
+	}
+}
+
+func TestAdmin_Withdraw(t *testing.T) {
+	bals := balances{precision: balancePrecisionGAS}
+	bals.init()
+
+	id := ownertest.ID()
+
+	bals.set(id, 20)
+
+	srv := &adminServer{
+		balances:    &bals,
+		withdrawFee: 2,
+	}
+
+	w := adminRequest(srv, http.MethodPost, "/balance/withdraw?owner="+id.String()+"&amount=10", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("withdraw: %d %s", w.Code, w.Body)
+	}
+
+	if v := bals.get(id); v != 8 {
+		t.Fatalf("unexpected balance after withdrawal %d", v)
+	}
+
+	// 8 covers the amount, but not the fee
+	w = adminRequest(srv, http.MethodPost, "/balance/withdraw?owner="+id.String()+"&amount=8", "")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("withdrawal without fee funds is accepted: %d", w.Code)
+	}
+}
+
+func TestAdmin_ReadOnlyRoutes(t *testing.T) {
+	srv := new(adminServer)
+
+	for _, route := range []string{
+		"/epoch",
+		"/eacl",
+		"/eacl/history",
+		"/eacls",
+		"/balance",
+		"/sessions",
+		"/containers",
+		"/objects",
+		"/object/header",
+	} {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+			w := adminRequest(srv, method, route, "")
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Fatalf("%s %s: unexpected status %d", method, route, w.Code)
+			}
+		}
+	}
+}
+
+func TestAdmin_EACLs(t *testing.T) {
+	nm := &testNetMap{epoch: 5}
+
+	srv := &adminServer{
+		containers: new(containers),
+	}
+
+	srv.containers.init()
+	srv.containers.netMap = nm
+
+	ids := []*cid.ID{cidtest.ID(), cidtest.ID()}
+
+	for _, id := range ids {
+		table := eacl.NewTable()
+		table.SetCID(id)
+
+		srv.containers.putEACLNow(table)
+	}
+
+	var res []struct {
+		Container string `json:"container"`
+		Epoch     uint64 `json:"epoch"`
+	}
+
+	w := adminRequest(srv, http.MethodGet, "/eacls", "")
+
+	err := json.Unmarshal(w.Body.Bytes(), &res)
+	if err != nil {
+		t.Fatalf("decode eACLs (%d %s): %v", w.Code, w.Body, err)
+	}
+
+	if len(res) != len(ids) {
+		t.Fatalf("unexpected number of eACLs %d", len(res))
+	}
+
+	for i := range res {
+		if res[i].Epoch != nm.epoch {
+			t.Fatalf("unexpected epoch %d of the eACL #%d", res[i].Epoch, i)
+		}
+	}
+
+	if res[0].Container > res[1].Container {
+		t.Fatal("eACLs are not sorted by container")
+	}
+}
+
+func TestAdmin_Wipe(t *testing.T) {
+	dir := t.TempDir()
+
+	cnrOwner := ownertest.ID()
+
+	cnr := container.New(container.WithOwnerID(cnrOwner))
+	idCnr := container.CalculateID(cnr)
+
+	newObject := func() *objectcore.Object {
+		obj := object.NewRaw()
+		obj.SetContainerID(idCnr)
+		obj.SetID(oidtest.ID())
+		obj.SetVersion(version.Current())
+		obj.SetOwnerID(cnrOwner)
+		object.CalculateAndSetPayloadChecksum(obj)
+
+		return objectcore.NewFromSDK(obj.Object())
+	}
+
+	fixtureObj := newObject()
+
+	bals := balances{precision: balancePrecisionGAS}
+	bals.init()
+
+	var sess sessions
+	sess.init()
+
+	// non-shard files in the storage directory are kept
+	otherFile := filepath.Join(dir, "sessions.db")
+
+	err := os.WriteFile(otherFile, nil, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events gcEvents
+
+	srv := &adminServer{
+		log:        zap.NewNop(),
+		containers: new(containers),
+		balances:   &bals,
+		sessions:   &sess,
+		fixtures: &fixtures{
+			containers: []vContainer{{id: idCnr, cnr: cnr}},
+			objects:    []*objectcore.Object{fixtureObj},
+		},
+		localObjects: newTestStorageEngine(t, dir, &events),
+		gcEvents:     &events,
+		storagePath:  dir,
+	}
+
+	srv.containers.init()
+	srv.containers.latency = time.Hour
+	srv.containers.netMap = newTestNetMap(1)
+
+	srv.fixtures.applyContainers(srv.containers)
+
+	err = srv.fixtures.storeObjects(srv.localObjects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// changes made before the wipe
+	table := eacl.NewTable()
+	table.SetCID(idCnr)
+
+	err = srv.containers.PutEACL(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = engine.Put(srv.localObjects, newObject())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		w := adminRequest(srv, http.MethodPost, "/wipe", "")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("wipe: %d %s", w.Code, w.Body)
+		}
+	}
+
+	srv.containers.mtxPending.Lock()
+	pending := len(srv.containers.pending)
+	srv.containers.mtxPending.Unlock()
+
+	if pending != 0 {
+		t.Fatalf("%d container changes are still pending", pending)
+	}
+
+	if _, err = os.Stat(otherFile); err != nil {
+		t.Fatalf("non-shard file is removed: %v", err)
+	}
+
+	if _, err = srv.containers.Get(idCnr); err != nil {
+		t.Fatalf("fixture container is not restored: %v", err)
+	}
+
+	var addrs []string
+
+	w := adminRequest(srv, http.MethodGet, "/objects?container="+idCnr.String(), "")
+
+	err = json.Unmarshal(w.Body.Bytes(), &addrs)
+	if err != nil {
+		t.Fatalf("decode objects (%d %s): %v", w.Code, w.Body, err)
+	}
+
+	if len(addrs) != 1 || !strings.HasSuffix(addrs[0], fixtureObj.ID().String()) {
+		t.Fatalf("unexpected objects after wipe: %v", addrs)
+	}
+
+	// GC listens epochs after wipe, otherwise new epoch blocks
+	done := make(chan struct{})
+
+	go func() {
+		events.newEpoch(1)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("new epoch is blocked")
+	}
+}
@@ -0,0 +1,76 @@
+package cngl
+
+import (
+	"math/big"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.uber.org/zap"
+)
+
+// charges container owners for the data stored in the local storage and
+// pays to the node owner like basic income settlement of the Balance contract does.
+type billing struct {
+	log *zap.Logger
+
+	balances *balances
+
+	containers *containers
+
+	localObjects *engine.StorageEngine
+
+	// GAS in Fixed8 per GiB per epoch
+	rate uint64
+
+	// receiver of the storage fees
+	nodeOwner *owner.ID
+}
+
+// returns storage fee in the ledger units for the given number of bytes.
+func (x *billing) storageFee(size uint64) int64 {
+	fee := new(big.Int).SetUint64(x.rate)
+	fee.Mul(fee, new(big.Int).SetUint64(size))
+	fee.Rsh(fee, 30)
+
+	return x.balances.fromFixed8(fee.Uint64())
+}
+
+// charges storage fees for all containers stored in the local storage.
+func (x *billing) chargeStorage(epoch uint64) {
+	if x.rate == 0 {
+		return
+	}
+
+	ids, err := engine.ListContainers(x.localObjects)
+	if err != nil {
+		x.log.Error("list containers for storage billing", zap.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		cnr, err := x.containers.Get(id)
+		if err != nil || cnr.OwnerID() == nil {
+			// container is removed, nobody to charge
+			continue
+		}
+
+		size, err := engine.ContainerSize(x.localObjects, id)
+		if err != nil {
+			x.log.Error("read size of the container for storage billing",
+				zap.Stringer("container", id), zap.Error(err))
+			continue
+		}
+
+		fee := x.storageFee(size)
+		if fee == 0 {
+			continue
+		}
+
+		err = x.balances.transfer(cnr.OwnerID(), x.nodeOwner, fee)
+		if err != nil {
+			x.log.Error("charge storage fee",
+				zap.Stringer("container", id), zap.Int64("fee", fee), zap.Uint64("size", size),
+				zap.Uint64("epoch", epoch), zap.Error(err))
+		}
+	}
+}
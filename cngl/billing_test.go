@@ -0,0 +1,115 @@
+package cngl
+
+import (
+	"path/filepath"
+	"testing"
+
+	objectcore "github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/blobstor"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	meta "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/metabase"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/shard"
+	"github.com/nspcc-dev/neofs-node/pkg/util"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+	"github.com/nspcc-dev/neofs-sdk-go/version"
+	"go.uber.org/zap"
+)
+
+// returns opened and initialized storage engine with single shard in the given
+// directory. GC of the shard listens the given epoch events.
+func newTestStorageEngine(t *testing.T, dir string, events *gcEvents) *engine.StorageEngine {
+	l := zap.NewNop()
+
+	e := engine.New(engine.WithLogger(l))
+
+	_, err := e.AddShard(
+		shard.WithLogger(l),
+		shard.WithWriteCache(false),
+		shard.WithGCWorkerPoolInitializer(func(int) util.WorkerPool {
+			return util.NewPseudoWorkerPool()
+		}),
+		shard.WithGCEventChannelInitializer(events.init),
+		shard.WithBlobStorOptions(
+			blobstor.WithLogger(l),
+			blobstor.WithRootPath(filepath.Join(dir, storageBlobDir)),
+		),
+		shard.WithMetaBaseOptions(
+			meta.WithLogger(l),
+			meta.WithPath(filepath.Join(dir, storageMetaDir)),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = e.Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = e.Close() })
+
+	return e
+}
+
+func TestBilling_ChargeStorage(t *testing.T) {
+	bals := balances{precision: balancePrecisionGAS}
+	bals.init()
+
+	var cnrs containers
+	cnrs.init()
+
+	cnrOwner, nodeOwner := ownertest.ID(), ownertest.ID()
+
+	bals.set(cnrOwner, 100)
+
+	cnr := container.New(container.WithOwnerID(cnrOwner))
+	idCnr := container.CalculateID(cnr)
+
+	cnrs.putWithID(idCnr, cnr)
+
+	payload := make([]byte, 10)
+
+	obj := object.NewRaw()
+	obj.SetContainerID(idCnr)
+	obj.SetID(oidtest.ID())
+	obj.SetVersion(version.Current())
+	obj.SetOwnerID(cnrOwner)
+	obj.SetPayload(payload)
+	obj.SetPayloadSize(uint64(len(payload)))
+	object.CalculateAndSetPayloadChecksum(obj)
+
+	e := newTestStorageEngine(t, t.TempDir(), new(gcEvents))
+
+	err := engine.Put(e, objectcore.NewFromSDK(obj.Object()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := billing{
+		balances:     &bals,
+		containers:   &cnrs,
+		localObjects: e,
+		// 1 Fixed8 per byte
+		rate:      1 << 30,
+		nodeOwner: nodeOwner,
+	}
+
+	b.chargeStorage(1)
+
+	if v := bals.get(cnrOwner); v != 90 {
+		t.Fatalf("unexpected balance of the container owner %d", v)
+	}
+
+	if v := bals.get(nodeOwner); v != 10 {
+		t.Fatalf("unexpected balance of the node owner %d", v)
+	}
+}
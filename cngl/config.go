@@ -0,0 +1,291 @@
+package cngl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/config"
+)
+
+type readConfigContext struct {
+	c config.Config
+}
+
+// reads settings from the config file.
+func (x *settings) readFile(fPath string) {
+	defer func() {
+		// config package panics on missing required and invalid values
+		if r := recover(); r != nil {
+			if _, ok := r.(prepareError); ok {
+				panic(r)
+			}
+
+			failPrepare("read config file %s: %v", fPath, r)
+		}
+	}()
+
+	var (
+		ctxRead   readConfigContext
+		prmConfig config.Prm
+	)
+
+	ctxRead.c = *config.New(prmConfig,
+		config.WithConfigFile(fPath),
+	)
+
+	x.readLogger(&ctxRead)
+	x.readBasics(&ctxRead)
+	x.readNetwork(&ctxRead)
+	x.readLocalNode(&ctxRead)
+	x.readGRPC(&ctxRead)
+	x.readAdmin(&ctxRead)
+	x.readMetrics(&ctxRead)
+	x.readStorage(&ctxRead)
+	x.readFixtures(&ctxRead)
+}
+
+func (x *settings) readLogger(ctx *readConfigContext) {
+	c := ctx.c.Sub("logger")
+
+	if v := config.StringSafe(c, "level"); v != "" {
+		x.logger.cfg.level = v
+	}
+
+	if v := config.StringSafe(c, "format"); v != "" {
+		x.logger.cfg.format = v
+	}
+
+	if v := config.StringSafe(c, "output"); v != "" {
+		x.logger.cfg.output = v
+	}
+}
+
+func (x *settings) readBasics(ctx *readConfigContext) {
+	x.basics.keyFilepath = config.String(&ctx.c, "basics.key.path")
+}
+
+func (x *settings) readLocalNode(ctx *readConfigContext) {
+	x.localNode.infoFilepath = config.String(&ctx.c, "local_node.info.path")
+}
+
+func (x *settings) readGRPC(ctx *readConfigContext) {
+	c := ctx.c.Sub("listen").Sub("grpc")
+
+	// single endpoint is still supported
+	if c.Sub("server").Value("endpoint") != nil {
+		x.grpc.endpoints = append(x.grpc.endpoints, readEndpoint(c.Sub("server")))
+	}
+
+	x.readEndpoints(c.Sub("servers"))
+
+	x.grpc.dump.enabled = config.BoolSafe(&ctx.c, "listen.grpc.dump.enabled")
+	x.grpc.dump.filters = config.StringSliceSafe(&ctx.c, "listen.grpc.dump.filters")
+
+	c = ctx.c.Sub("listen").Sub("grpc").Sub("traffic_log")
+	x.grpc.trafficLog.path = config.StringSafe(c, "path")
+	x.grpc.trafficLog.maxSize = int64(config.SizeInBytesSafe(c, "max_size"))
+	x.grpc.trafficLog.maxBackups = int(config.UintSafe(c, "max_backups"))
+	x.grpc.trafficLog.buffer = int(config.UintSafe(c, "buffer"))
+	x.grpc.trafficLog.lossless = config.BoolSafe(c, "lossless")
+
+	x.grpc.mockFilepath = config.StringSafe(&ctx.c, "listen.grpc.mock.path")
+
+	c = ctx.c.Sub("listen").Sub("grpc").Sub("faults")
+	x.grpc.faults.seed = config.IntSafe(c, "seed")
+	x.readFaultRules(c.Sub("rules"))
+}
+
+// reads gRPC endpoints from the numbered subsections: servers.0, servers.1, etc.
+func (x *settings) readEndpoints(c *config.Config) {
+	for i := 0; ; i++ {
+		cEndpoint := c.Sub(strconv.Itoa(i))
+
+		if cEndpoint.Value("endpoint") == nil {
+			break
+		}
+
+		x.grpc.endpoints = append(x.grpc.endpoints, readEndpoint(cEndpoint))
+	}
+}
+
+func readEndpoint(c *config.Config) cfgEndpoint {
+	cTLS := c.Sub("tls")
+
+	return cfgEndpoint{
+		address: config.String(c, "endpoint"),
+		tls: cfgTLS{
+			enabled:          config.BoolSafe(cTLS, "enabled"),
+			certFilepath:     config.StringSafe(cTLS, "certificate"),
+			keyFilepath:      config.StringSafe(cTLS, "key"),
+			clientCAFilepath: config.StringSafe(cTLS, "client_ca"),
+			selfSigned:       config.BoolSafe(cTLS, "self_signed"),
+		},
+	}
+}
+
+// reads fault rules from the numbered subsections: rules.0, rules.1, etc.
+func (x *settings) readFaultRules(c *config.Config) {
+	for i := 0; ; i++ {
+		cRule := c.Sub(strconv.Itoa(i))
+
+		if cRule.Value("method") == nil {
+			break
+		}
+
+		rule := cfgFaultRule{
+			Method:    config.StringSafe(cRule, "method"),
+			Status:    config.StringSafe(cRule, "status"),
+			Message:   config.StringSafe(cRule, "message"),
+			Latency:   config.StringSafe(cRule, "latency"),
+			DropAfter: config.UintSafe(cRule, "drop_after"),
+		}
+
+		if cRule.Value("percent") != nil {
+			percent := config.Uint(cRule, "percent")
+			rule.Percent = &percent
+		}
+
+		x.grpc.faults.rules = append(x.grpc.faults.rules, rule)
+	}
+}
+
+func (x *settings) readAdmin(ctx *readConfigContext) {
+	x.admin.listenAddress = config.StringSafe(&ctx.c, "listen.admin.endpoint")
+}
+
+func (x *settings) readMetrics(ctx *readConfigContext) {
+	x.metrics.listenAddress = config.StringSafe(&ctx.c, "listen.metrics.endpoint")
+}
+
+func (x *settings) readNetwork(ctx *readConfigContext) {
+	c := ctx.c.Sub("network")
+	x.network.ir.keysStr = config.StringSlice(c, "inner_ring.keys")
+
+	// keep the default epoch if not set
+	if v := config.UintSafe(c, "netmap.epoch"); v != 0 {
+		x.network.netMap.epoch = v
+	}
+
+	x.network.netMap.autoTick = config.BoolSafe(c, "netmap.auto_tick")
+	x.network.netMap.retention = config.UintSafe(c, "netmap.retention")
+	x.network.netMap.nodesFilepath = config.StringSafe(c, "netmap.nodes_file")
+	x.readNetMapNodes(c.Sub("netmap").Sub("nodes"))
+	x.readNetMapEvents(c.Sub("netmap").Sub("events"))
+	x.network.containers.latencyBlocks = config.UintSafe(c, "containers.latency.blocks")
+	x.network.containers.latencyDuration = config.DurationSafe(c, "containers.latency.duration")
+	x.network.containers.lenientPlacement = config.BoolSafe(c, "containers.placement.lenient")
+
+	x.readNetworkParameters(c.Sub("parameters"))
+	x.readAccounting(c.Sub("accounting"))
+}
+
+func (x *settings) readAccounting(c *config.Config) {
+	x.network.accounting.precision = uint32(uintOrDefault(c, "precision", balancePrecisionGAS))
+	x.network.accounting.defaultBalance = config.IntSafe(c, "default_balance")
+	x.network.accounting.balances = config.StringSliceSafe(c, "balances")
+	x.network.accounting.ledgerFilepath = config.StringSafe(c, "ledger_file")
+}
+
+// reads virtual nodes from the numbered subsections: nodes.0, nodes.1, etc.
+func (x *settings) readNetMapNodes(c *config.Config) {
+	for i := 0; ; i++ {
+		cNode := c.Sub(strconv.Itoa(i))
+
+		key := config.StringSafe(cNode, "key")
+		if key == "" {
+			break
+		}
+
+		x.network.netMap.nodes = append(x.network.netMap.nodes, cfgNode{
+			key:        key,
+			addresses:  config.StringSliceSafe(cNode, "addresses"),
+			attributes: config.StringSliceSafe(cNode, "attributes"),
+		})
+	}
+}
+
+// reads scheduled node state changes from the numbered subsections: events.0, events.1, etc.
+func (x *settings) readNetMapEvents(c *config.Config) {
+	for i := 0; ; i++ {
+		cEvent := c.Sub(strconv.Itoa(i))
+
+		key := config.StringSafe(cEvent, "key")
+		if key == "" {
+			break
+		}
+
+		x.network.netMap.events = append(x.network.netMap.events, cfgNodeEvent{
+			epoch: config.Uint(cEvent, "epoch"),
+			key:   key,
+			state: config.String(cEvent, "state"),
+		})
+	}
+}
+
+// default values of the network parameters.
+const (
+	defaultNetworkMagic         = 1337
+	defaultMsPerBlock           = 15000
+	defaultEpochDuration        = 20
+	defaultMaxObjectSize        = 64 << 20
+	defaultEigenTrustIterations = 4
+	defaultEigenTrustAlpha      = 0.1
+)
+
+// reads value by name and casts it to uint64, returns def if value is missing.
+func uintOrDefault(c *config.Config, name string, def uint64) uint64 {
+	if c.Value(name) == nil {
+		return def
+	}
+
+	return config.Uint(c, name)
+}
+
+func (x *settings) readNetworkParameters(c *config.Config) {
+	prm := &x.network.parameters
+
+	prm.magic = uintOrDefault(c, "magic", defaultNetworkMagic)
+	prm.msPerBlock = int64(uintOrDefault(c, "ms_per_block", defaultMsPerBlock))
+	prm.epochDuration = uintOrDefault(c, "epoch_duration", defaultEpochDuration)
+	prm.maxObjectSize = uintOrDefault(c, "max_object_size", defaultMaxObjectSize)
+	prm.basicIncomeRate = config.UintSafe(c, "basic_income_rate")
+	prm.auditFee = config.UintSafe(c, "audit_fee")
+	prm.containerFee = config.UintSafe(c, "container_fee")
+	prm.containerAliasFee = config.UintSafe(c, "container_alias_fee")
+	prm.eigenTrustIterations = uintOrDefault(c, "eigen_trust_iterations", defaultEigenTrustIterations)
+	prm.innerRingCandidateFee = config.UintSafe(c, "inner_ring_candidate_fee")
+	prm.withdrawFee = config.UintSafe(c, "withdraw_fee")
+
+	prm.eigenTrustAlpha = defaultEigenTrustAlpha
+	if c.Value("eigen_trust_alpha") != nil {
+		var err error
+
+		prm.eigenTrustAlpha, err = strconv.ParseFloat(config.String(c, "eigen_trust_alpha"), 64)
+		if err != nil {
+			failPrepare("invalid EigenTrust alpha: %v", err)
+		}
+	}
+
+	// custom parameters are listed as KEY=VALUE since config keys are case-insensitive
+	for _, kv := range config.StringSliceSafe(c, "custom") {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			failPrepare("invalid custom network parameter %q, expected KEY=VALUE", kv)
+		}
+
+		prm.custom = append(prm.custom, [2]string{kv[:i], kv[i+1:]})
+	}
+}
+
+func (x *settings) readStorage(ctx *readConfigContext) {
+	x.storage.localObjectsFilepath = config.String(&ctx.c, "storage.path")
+	x.storage.sessionsFilepath = config.StringSafe(&ctx.c, "storage.sessions.path")
+	x.storage.maxSessionsPerOwner = config.UintSafe(&ctx.c, "storage.sessions.max_per_owner")
+}
+
+func (x *settings) readFixtures(ctx *readConfigContext) {
+	c := ctx.c.Sub("fixtures")
+	x.fixtures.containersFilepaths = config.StringSliceSafe(c, "containers")
+	x.fixtures.eACLFilepaths = config.StringSliceSafe(c, "eacl")
+	x.fixtures.objectsFilepaths = config.StringSliceSafe(c, "objects")
+}
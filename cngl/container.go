@@ -0,0 +1,449 @@
+package cngl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	containercore "github.com/nspcc-dev/neofs-node/pkg/core/container"
+	netmapcore "github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/eacl"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.uber.org/zap"
+)
+
+type vContainer struct {
+	id *cid.ID
+
+	cnr *container.Container
+}
+
+// eACL table set at some epoch.
+type eACLRecord struct {
+	epoch uint64
+
+	table *eacl.Table
+}
+
+type containers struct {
+	log *zap.Logger
+
+	// delay between accepting the change and applying it to the state,
+	// simulates processing of the request by the Inner Ring
+	latency time.Duration
+
+	// source of the network map to check placement policies against
+	netMap netmapcore.Source
+
+	// log unsatisfiable placement policies instead of rejecting the containers
+	lenientPlacement bool
+
+	// ledger to charge container creation fee from, fees are not charged if nil
+	balances *balances
+	// container creation fee in the ledger units
+	fee int64
+
+	mtxContainers sync.RWMutex
+	mContainers   map[string]vContainer
+	// owner ID -> set of container IDs
+	mOwners map[string]map[string]struct{}
+
+	mtxEACL sync.RWMutex
+	// container ID -> history of eACL tables ordered by setting time, last is current
+	mEACL map[string][]eACLRecord
+
+	// held while delayed change is applied
+	mtxPending sync.Mutex
+	// changes waiting for the latency with their cancellation handlers
+	pending map[*time.Timer]func()
+}
+
+func (x *containers) init() {
+	x.pending = make(map[*time.Timer]func())
+	x.mContainers = make(map[string]vContainer)
+	x.mOwners = make(map[string]map[string]struct{})
+	x.mEACL = make(map[string][]eACLRecord)
+}
+
+// removes all containers and eACL tables, pending changes are canceled.
+func (x *containers) reset() {
+	x.mtxPending.Lock()
+	defer x.mtxPending.Unlock()
+
+	x.cancelPending()
+
+	x.mtxContainers.Lock()
+	x.mContainers = make(map[string]vContainer)
+	x.mOwners = make(map[string]map[string]struct{})
+	x.mtxContainers.Unlock()
+
+	x.mtxEACL.Lock()
+	x.mEACL = make(map[string][]eACLRecord)
+	x.mtxEACL.Unlock()
+}
+
+// applies f to the state after the configured latency. Returns immediately.
+func (x *containers) apply(f func()) {
+	x.applyOrCancel(f, nil)
+}
+
+// same as apply, but calls onCancel instead of f if the change is canceled
+// before the latency expires. onCancel may be nil.
+func (x *containers) applyOrCancel(f, onCancel func()) {
+	if x.latency <= 0 {
+		f()
+		return
+	}
+
+	x.mtxPending.Lock()
+	defer x.mtxPending.Unlock()
+
+	var t *time.Timer
+
+	// lock is held, so t is assigned before f is called
+	t = time.AfterFunc(x.latency, func() {
+		x.mtxPending.Lock()
+		defer x.mtxPending.Unlock()
+
+		// timer could fire right before cancellation
+		if _, ok := x.pending[t]; ok {
+			delete(x.pending, t)
+			f()
+		}
+	})
+
+	x.pending[t] = onCancel
+}
+
+// stops all changes waiting for the latency. Must be called under mtxPending.
+func (x *containers) cancelPending() {
+	for t, onCancel := range x.pending {
+		t.Stop()
+		delete(x.pending, t)
+
+		if onCancel != nil {
+			onCancel()
+		}
+	}
+}
+
+// cancels pending changes, they are not applied after the call.
+func (x *containers) stop() {
+	x.mtxPending.Lock()
+	x.cancelPending()
+	x.mtxPending.Unlock()
+}
+
+func (x *containers) Delete(witness containercore.RemovalWitness) error {
+	strID := witness.ContainerID().String()
+
+	x.apply(func() {
+		x.mtxContainers.Lock()
+
+		if v, ok := x.mContainers[strID]; ok {
+			x.unindexOwner(strID, v.cnr)
+			delete(x.mContainers, strID)
+		}
+
+		x.mtxContainers.Unlock()
+
+		x.mtxEACL.Lock()
+		delete(x.mEACL, strID)
+		x.mtxEACL.Unlock()
+	})
+
+	return nil
+}
+
+func (x *containers) PutEACL(table *eacl.Table) error {
+	x.apply(func() {
+		x.putEACLNow(table)
+	})
+
+	return nil
+}
+
+// saves eACL table in the state bypassing the latency. The table
+// becomes current and is added to the history with the current epoch.
+func (x *containers) putEACLNow(table *eacl.Table) {
+	epoch, err := x.netMap.Epoch()
+	if err != nil {
+		x.log.Error("read current epoch for eACL history", zap.Error(err))
+	}
+
+	strID := table.CID().String()
+
+	x.mtxEACL.Lock()
+
+	x.mEACL[strID] = append(x.mEACL[strID], eACLRecord{
+		epoch: epoch,
+		table: table,
+	})
+
+	x.mtxEACL.Unlock()
+}
+
+func (x *containers) Put(cnr *container.Container) (*cid.ID, error) {
+	id := container.CalculateID(cnr)
+
+	err := x.checkPlacement(cnr)
+	if err != nil {
+		if !x.lenientPlacement {
+			return nil, fmt.Errorf("invalid placement policy: %w", err)
+		}
+
+		x.log.Warn("placement policy of the container can not be satisfied",
+			zap.Stringer("container", id), zap.Error(err))
+	}
+
+	var refund func()
+
+	if x.balances != nil && x.fee > 0 {
+		ownerID := cnr.OwnerID()
+		if ownerID == nil {
+			return nil, errors.New("missing container owner")
+		}
+
+		_, err = x.balances.withdraw(ownerID, x.fee)
+		if err != nil {
+			return nil, fmt.Errorf("charge container fee %d: %w", x.fee, err)
+		}
+
+		// fee is returned if container is not created
+		refund = func() {
+			x.balances.deposit(ownerID, x.fee)
+		}
+	}
+
+	x.applyOrCancel(func() {
+		x.putWithID(id, cnr)
+	}, refund)
+
+	return id, nil
+}
+
+// checks if placement policy of the container can be satisfied by the current network map.
+func (x *containers) checkPlacement(cnr *container.Container) error {
+	p := cnr.PlacementPolicy()
+	if p == nil {
+		return errors.New("missing placement policy")
+	}
+
+	nm, err := x.netMap.GetNetMap(0)
+	if err != nil {
+		return fmt.Errorf("read current network map: %w", err)
+	}
+
+	strPolicy := replicasToString(p)
+
+	vectors, err := nm.GetContainerNodes(p, nil)
+	if err != nil {
+		return fmt.Errorf("apply policy [%s] to the network map with %d nodes: %w", strPolicy, len(nm.Nodes), err)
+	}
+
+	replicas := p.Replicas()
+
+	for i, nodes := range vectors.Replicas() {
+		if n := uint32(len(nodes)); n < replicas[i].Count() {
+			return fmt.Errorf("policy [%s]: replica #%d requires %d nodes, network map provides %d",
+				strPolicy, i, replicas[i].Count(), n)
+		}
+	}
+
+	return nil
+}
+
+// returns replica statements of the placement policy in SQL-like form, e.g. "REP 2 IN X REP 1".
+func replicasToString(p *netmap.PlacementPolicy) string {
+	var sb strings.Builder
+
+	for i, r := range p.Replicas() {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(fmt.Sprintf("REP %d", r.Count()))
+
+		if sel := r.Selector(); sel != "" {
+			sb.WriteString(" IN " + sel)
+		}
+	}
+
+	return sb.String()
+}
+
+// saves container in the state under the given ID bypassing the latency.
+func (x *containers) putWithID(id *cid.ID, cnr *container.Container) {
+	strID := id.String()
+
+	x.mtxContainers.Lock()
+
+	if v, ok := x.mContainers[strID]; ok {
+		x.unindexOwner(strID, v.cnr)
+	}
+
+	x.mContainers[strID] = vContainer{
+		id:  id,
+		cnr: cnr,
+	}
+
+	x.indexOwner(strID, cnr)
+
+	x.mtxContainers.Unlock()
+}
+
+// returns string key of the container owner in the owner index.
+// Containers without owner are indexed under empty key.
+func ownerKey(cnr *container.Container) string {
+	if id := cnr.OwnerID(); id != nil {
+		return id.String()
+	}
+
+	return ""
+}
+
+// must be called under write lock.
+func (x *containers) indexOwner(strID string, cnr *container.Container) {
+	key := ownerKey(cnr)
+
+	ids, ok := x.mOwners[key]
+	if !ok {
+		ids = make(map[string]struct{})
+		x.mOwners[key] = ids
+	}
+
+	ids[strID] = struct{}{}
+}
+
+// must be called under write lock.
+func (x *containers) unindexOwner(strID string, cnr *container.Container) {
+	key := ownerKey(cnr)
+
+	if ids, ok := x.mOwners[key]; ok {
+		delete(ids, strID)
+
+		if len(ids) == 0 {
+			delete(x.mOwners, key)
+		}
+	}
+}
+
+// List returns IDs of the containers which belong to the owner sorted by
+// string representation. Returns IDs of all containers if owner is nil.
+func (x *containers) List(id *owner.ID) ([]*cid.ID, error) {
+	x.mtxContainers.RLock()
+	defer x.mtxContainers.RUnlock()
+
+	var strIDs []string
+
+	if id == nil {
+		strIDs = make([]string, 0, len(x.mContainers))
+
+		for strID := range x.mContainers {
+			strIDs = append(strIDs, strID)
+		}
+	} else {
+		ids := x.mOwners[id.String()]
+
+		strIDs = make([]string, 0, len(ids))
+
+		for strID := range ids {
+			strIDs = append(strIDs, strID)
+		}
+	}
+
+	sort.Strings(strIDs)
+
+	res := make([]*cid.ID, len(strIDs))
+
+	for i := range strIDs {
+		res[i] = x.mContainers[strIDs[i]].id
+	}
+
+	return res, nil
+}
+
+func (x *containers) Get(id *cid.ID) (*container.Container, error) {
+	x.mtxContainers.RLock()
+	defer x.mtxContainers.RUnlock()
+
+	v, ok := x.mContainers[id.String()]
+	if !ok {
+		return nil, containercore.ErrNotFound
+	}
+
+	return v.cnr, nil
+}
+
+func (x *containers) GetEACL(id *cid.ID) (*eacl.Table, error) {
+	x.mtxEACL.RLock()
+	defer x.mtxEACL.RUnlock()
+
+	history := x.mEACL[id.String()]
+	if len(history) == 0 {
+		return nil, containercore.ErrEACLNotFound
+	}
+
+	return history[len(history)-1].table, nil
+}
+
+// returns eACL table which was in force at the given epoch, i.e. the last one
+// set at the epoch or before.
+func (x *containers) eACLAtEpoch(id *cid.ID, epoch uint64) (eACLRecord, bool) {
+	x.mtxEACL.RLock()
+	defer x.mtxEACL.RUnlock()
+
+	history := x.mEACL[id.String()]
+
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].epoch <= epoch {
+			return history[i], true
+		}
+	}
+
+	return eACLRecord{}, false
+}
+
+// returns current eACL tables of all containers sorted by container ID.
+func (x *containers) currentEACLs() []eACLRecord {
+	x.mtxEACL.RLock()
+	defer x.mtxEACL.RUnlock()
+
+	strIDs := make([]string, 0, len(x.mEACL))
+
+	for strID, history := range x.mEACL {
+		if len(history) > 0 {
+			strIDs = append(strIDs, strID)
+		}
+	}
+
+	sort.Strings(strIDs)
+
+	res := make([]eACLRecord, len(strIDs))
+
+	for i := range strIDs {
+		history := x.mEACL[strIDs[i]]
+		res[i] = history[len(history)-1]
+	}
+
+	return res
+}
+
+// returns copy of the eACL history of the container.
+func (x *containers) eACLHistory(id *cid.ID) []eACLRecord {
+	x.mtxEACL.RLock()
+	defer x.mtxEACL.RUnlock()
+
+	history := x.mEACL[id.String()]
+
+	res := make([]eACLRecord, len(history))
+	copy(res, history)
+
+	return res
+}
@@ -0,0 +1,322 @@
+package cngl
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	containercore "github.com/nspcc-dev/neofs-node/pkg/core/container"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/eacl"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// static network map source with controllable epoch.
+type testNetMap struct {
+	nm netmap.Netmap
+
+	epoch uint64
+}
+
+func (x *testNetMap) GetNetMap(uint64) (*netmap.Netmap, error) {
+	return &x.nm, nil
+}
+
+func (x *testNetMap) GetNetMapByEpoch(uint64) (*netmap.Netmap, error) {
+	return &x.nm, nil
+}
+
+func (x *testNetMap) Epoch() (uint64, error) {
+	return x.epoch, nil
+}
+
+func (x *testNetMap) CurrentEpoch() uint64 {
+	return x.epoch
+}
+
+// returns network map with n nodes.
+func newTestNetMap(n int) *testNetMap {
+	infos := make([]netmap.NodeInfo, n)
+
+	for i := range infos {
+		infos[i] = *netmap.NewNodeInfo()
+		infos[i].SetPublicKey([]byte("node" + strconv.Itoa(i)))
+		infos[i].SetState(netmap.NodeStateOnline)
+	}
+
+	var res testNetMap
+	res.nm.Nodes = netmap.NodesFromInfo(infos)
+
+	return &res
+}
+
+// returns container with the placement policy of single replica in n nodes.
+func newTestContainer(n uint32) *container.Container {
+	r := netmap.NewReplica()
+	r.SetCount(n)
+
+	p := netmap.NewPlacementPolicy()
+	p.SetReplicas(r)
+
+	return container.New(container.WithPolicy(p))
+}
+
+func TestContainers_Latency(t *testing.T) {
+	var x containers
+	x.init()
+	x.latency = 50 * time.Millisecond
+	x.netMap = newTestNetMap(1)
+
+	id, err := x.Put(newTestContainer(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = x.Get(id)
+	if !errors.Is(err, containercore.ErrNotFound) {
+		t.Fatalf("container is applied before the latency: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+
+	for {
+		_, err = x.Get(id)
+		if err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("container is not applied after the latency: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var witness containercore.RemovalWitness
+	witness.SetContainerID(id)
+
+	err = x.Delete(witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x.stop()
+
+	time.Sleep(2 * x.latency)
+
+	_, err = x.Get(id)
+	if err != nil {
+		t.Fatalf("canceled removal is applied: %v", err)
+	}
+
+	x.mtxPending.Lock()
+	pending := len(x.pending)
+	x.mtxPending.Unlock()
+
+	if pending != 0 {
+		t.Fatalf("%d changes are still pending", pending)
+	}
+}
+
+func TestContainers_Placement(t *testing.T) {
+	var x containers
+	x.init()
+	x.netMap = newTestNetMap(2)
+
+	_, err := x.Put(newTestContainer(2))
+	if err != nil {
+		t.Fatalf("satisfiable policy is rejected: %v", err)
+	}
+
+	cnr := newTestContainer(3)
+
+	_, err = x.Put(cnr)
+	if err == nil {
+		t.Fatal("unsatisfiable policy is accepted")
+	}
+
+	core, logs := observer.New(zapcore.WarnLevel)
+
+	x.log = zap.New(core)
+	x.lenientPlacement = true
+
+	id, err := x.Put(cnr)
+	if err != nil {
+		t.Fatalf("unsatisfiable policy is rejected in lenient mode: %v", err)
+	}
+
+	if logs.FilterField(zap.Stringer("container", id)).Len() != 1 {
+		t.Fatalf("unsatisfiable policy is not logged: %v", logs.All())
+	}
+
+	_, err = x.Get(id)
+	if err != nil {
+		t.Fatalf("container is not saved in lenient mode: %v", err)
+	}
+}
+
+func TestContainers_ListByOwner(t *testing.T) {
+	var x containers
+
+	x.init()
+
+	owner1, owner2 := ownertest.ID(), ownertest.ID()
+
+	put := func(cnr *container.Container) string {
+		id := container.CalculateID(cnr)
+		x.putWithID(id, cnr)
+
+		return id.String()
+	}
+
+	id1 := put(container.New(container.WithOwnerID(owner1)))
+	id2 := put(container.New(container.WithOwnerID(owner1)))
+	id3 := put(container.New(container.WithOwnerID(owner2)))
+
+	check := func(id *owner.ID, exp ...string) {
+		ids, err := x.List(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ids) != len(exp) {
+			t.Fatalf("expected %d containers, got %d", len(exp), len(ids))
+		}
+
+		// IDs are sorted
+		sort.Strings(exp)
+
+		for i := range ids {
+			if ids[i].String() != exp[i] {
+				t.Fatalf("unexpected container #%d %s, expected %s", i, ids[i], exp[i])
+			}
+		}
+	}
+
+	check(owner1, id1, id2)
+	check(owner2, id3)
+	check(nil, id1, id2, id3)
+
+	var witness containercore.RemovalWitness
+
+	ids, err := x.List(owner2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	witness.SetContainerID(ids[0])
+
+	err = x.Delete(witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check(owner2)
+	check(nil, id1, id2)
+
+	x.reset()
+
+	check(owner1)
+	check(nil)
+}
+
+func TestContainers_EACLHistory(t *testing.T) {
+	var x containers
+	x.init()
+
+	nm := newTestNetMap(1)
+	x.netMap = nm
+
+	id := cidtest.ID()
+
+	put := func(epoch uint64) *eacl.Table {
+		nm.epoch = epoch
+
+		table := eacl.NewTable()
+		table.SetCID(id)
+
+		err := x.PutEACL(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return table
+	}
+
+	table1 := put(1)
+	table2 := put(3)
+
+	if _, ok := x.eACLAtEpoch(id, 0); ok {
+		t.Fatal("eACL is found before it was set")
+	}
+
+	for _, tc := range []struct {
+		epoch uint64
+		exp   *eacl.Table
+	}{
+		{epoch: 1, exp: table1},
+		{epoch: 2, exp: table1},
+		{epoch: 3, exp: table2},
+		{epoch: 10, exp: table2},
+	} {
+		rec, ok := x.eACLAtEpoch(id, tc.epoch)
+		if !ok || rec.table != tc.exp {
+			t.Fatalf("unexpected eACL at epoch %d", tc.epoch)
+		}
+	}
+
+	cur, err := x.GetEACL(id)
+	if err != nil || cur != table2 {
+		t.Fatalf("last eACL is not current: %v", err)
+	}
+
+	history := x.eACLHistory(id)
+	if len(history) != 2 || history[0].epoch != 1 || history[1].epoch != 3 {
+		t.Fatalf("unexpected eACL history %v", history)
+	}
+}
+
+func TestContainers_Fee(t *testing.T) {
+	bals := balances{precision: balancePrecisionGAS}
+	bals.init()
+
+	var x containers
+	x.init()
+	x.netMap = newTestNetMap(1)
+	x.balances = &bals
+	x.fee = 5
+	x.latency = time.Hour
+
+	cnrOwner := ownertest.ID()
+
+	bals.set(cnrOwner, 7)
+
+	cnr := newTestContainer(1)
+	cnr.SetOwnerID(cnrOwner)
+
+	_, err := x.Put(cnr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := bals.get(cnrOwner); v != 2 {
+		t.Fatalf("container fee is not charged, balance %d", v)
+	}
+
+	_, err = x.Put(cnr)
+	if !errors.Is(err, errInsufficientFunds) {
+		t.Fatalf("container is accepted without funds: %v", err)
+	}
+
+	x.stop()
+
+	if v := bals.get(cnrOwner); v != 7 {
+		t.Fatalf("fee of the canceled container is not refunded, balance %d", v)
+	}
+}
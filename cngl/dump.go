@@ -0,0 +1,163 @@
+package cngl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-api-go/v2/rpc/message"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type requestProcLogger struct {
+	// logger of the call
+	log *zap.Logger
+
+	mtx sync.Mutex
+	s   strings.Builder
+}
+
+var jsonDumpOpts = protojson.MarshalOptions{
+	Multiline:       true,
+	EmitUnpopulated: true,
+}
+
+// encodes message to JSON using given protojson options.
+func encodeMessage(opts protojson.MarshalOptions, msg interface{}) ([]byte, error) {
+	switch v := msg.(type) {
+	default:
+		return nil, fmt.Errorf("unsupported message type %T, must be proto.Message, json.Marshaler or message.Message", msg)
+	case proto.Message:
+		return opts.Marshal(v)
+	case json.Marshaler:
+		return v.MarshalJSON()
+	case message.Message:
+		return opts.Marshal(v.ToGRPCMessage().(proto.Message))
+	}
+}
+
+func printMessage(dst *requestProcLogger, title string, msg interface{}) {
+	jTxt, err := encodeMessage(jsonDumpOpts, msg)
+	if err != nil {
+		dst.writeString(fmt.Sprintf("%s: failed to encode %T: %v\n", title, msg, err))
+		return
+	}
+
+	dst.writeString(fmt.Sprintf("%s\n%s\n%T %s\n%s\n",
+		title,
+		"↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓",
+		msg,
+		string(jTxt),
+		"↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑↑",
+	))
+}
+
+func (x *requestProcLogger) writeString(s string) {
+	x.mtx.Lock()
+	x.s.WriteString(s + "\n")
+	x.mtx.Unlock()
+}
+
+// dumps requests and responses of the gRPC calls to the log.
+type requestDumper struct {
+	log *zap.Logger
+
+	// services or methods to dump in Service or Service/Method format,
+	// all calls are dumped if empty
+	filters []string
+}
+
+// checks if the call with the given full method name (/Service/Method) should be dumped.
+func (x *requestDumper) match(fullMethod string) bool {
+	if len(x.filters) == 0 {
+		return true
+	}
+
+	name := strings.TrimPrefix(fullMethod, "/")
+
+	for _, f := range x.filters {
+		if name == f || strings.HasPrefix(name, f+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writes collected dump of the call to the log. Dump is a part of the message
+// to keep it readable in the console format.
+func (x *requestDumper) free(l *requestProcLogger) {
+	l.log.Info("gRPC call dump\n" + l.s.String())
+}
+
+func (x *requestDumper) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !x.match(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	l := &requestProcLogger{
+		log: loggerFromContext(ctx, x.log.With(zap.String("method", info.FullMethod))),
+	}
+
+	printMessage(l, "request", req)
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		l.writeString(fmt.Sprintf("error: %v", err))
+	} else {
+		printMessage(l, "response", resp)
+	}
+
+	x.free(l)
+
+	return resp, err
+}
+
+func (x *requestDumper) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !x.match(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	l := &requestProcLogger{
+		log: loggerFromContext(ss.Context(), x.log.With(zap.String("method", info.FullMethod))),
+	}
+
+	err := handler(srv, &dumpServerStream{
+		ServerStream: ss,
+		l:            l,
+	})
+	if err != nil {
+		l.writeString(fmt.Sprintf("error: %v", err))
+	}
+
+	x.free(l)
+
+	return err
+}
+
+// grpc.ServerStream which dumps all received and sent messages.
+type dumpServerStream struct {
+	grpc.ServerStream
+
+	l *requestProcLogger
+}
+
+func (x *dumpServerStream) RecvMsg(m interface{}) error {
+	err := x.ServerStream.RecvMsg(m)
+	if err == nil {
+		printMessage(x.l, "request", m)
+	}
+
+	return err
+}
+
+func (x *dumpServerStream) SendMsg(m interface{}) error {
+	printMessage(x.l, "response", m)
+
+	return x.ServerStream.SendMsg(m)
+}
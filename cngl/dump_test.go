@@ -0,0 +1,88 @@
+package cngl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRequestDumper_Match(t *testing.T) {
+	d := requestDumper{
+		filters: []string{"neo.fs.v2.object.ObjectService", "neo.fs.v2.session.SessionService/Create"},
+	}
+
+	for method, exp := range map[string]bool{
+		"/neo.fs.v2.object.ObjectService/Put":           true,
+		"/neo.fs.v2.object.ObjectService/Get":           true,
+		"/neo.fs.v2.object.ObjectServiceExt/Get":        false,
+		"/neo.fs.v2.session.SessionService/Create":      true,
+		"/neo.fs.v2.container.ContainerService/Put":     false,
+		"/neo.fs.v2.accounting.AccountingService/Check": false,
+	} {
+		if d.match(method) != exp {
+			t.Fatalf("unexpected match result for %s", method)
+		}
+	}
+
+	if !new(requestDumper).match("/any.Service/Method") {
+		t.Fatal("calls must be dumped without filters")
+	}
+}
+
+func TestRequestDumper_UnaryInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	d := requestDumper{
+		log:     zap.New(core),
+		filters: []string{"test.Service/Dumped"},
+	}
+
+	call := func(method string, handlerErr error) {
+		_, err := d.unaryInterceptor(context.Background(), wrapperspb.String("request value"),
+			&grpc.UnaryServerInfo{FullMethod: method},
+			func(context.Context, interface{}) (interface{}, error) {
+				if handlerErr != nil {
+					return nil, handlerErr
+				}
+
+				return wrapperspb.String("response value"), nil
+			})
+		if !errors.Is(err, handlerErr) {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	call("/test.Service/Skipped", nil)
+
+	if logs.Len() != 0 {
+		t.Fatalf("filtered call is dumped: %v", logs.All())
+	}
+
+	call("/test.Service/Dumped", nil)
+
+	entry := logs.TakeAll()[0]
+	if method := entry.ContextMap()["method"]; method != "/test.Service/Dumped" {
+		t.Fatalf("unexpected method %v", method)
+	}
+
+	dump := entry.Message
+
+	for _, s := range []string{"request value", "response value"} {
+		if !strings.Contains(dump, s) {
+			t.Fatalf("dump does not contain %q: %s", s, dump)
+		}
+	}
+
+	call("/test.Service/Dumped", errors.New("handler failure"))
+
+	if dump = logs.TakeAll()[0].Message; !strings.Contains(dump, "error: handler failure") {
+		t.Fatalf("dump does not contain handler error: %s", dump)
+	}
+}
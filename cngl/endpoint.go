@@ -0,0 +1,160 @@
+package cngl
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// prefix of the Unix domain socket endpoints.
+const unixEndpointPrefix = "unix:"
+
+// description of the gRPC endpoint in the config.
+type cfgEndpoint struct {
+	// host:port or unix:<socket path>
+	address string
+
+	tls cfgTLS
+
+	// overrides tls
+	tlsConfig *tls.Config
+}
+
+// gRPC endpoint ready to listen.
+type grpcEndpoint struct {
+	// tcp or unix
+	network string
+
+	address string
+
+	// nil for plain connections
+	tls *tls.Config
+}
+
+// parses endpoint address in host:port or unix:<socket path> format.
+func parseEndpoint(s string) grpcEndpoint {
+	if strings.HasPrefix(s, unixEndpointPrefix) {
+		// both unix:/path and unix:///path are accepted
+		return grpcEndpoint{
+			network: "unix",
+			address: strings.TrimPrefix(strings.TrimPrefix(s, unixEndpointPrefix), "//"),
+		}
+	}
+
+	return grpcEndpoint{
+		network: "tcp",
+		address: s,
+	}
+}
+
+func (x grpcEndpoint) String() string {
+	if x.network == "unix" {
+		return unixEndpointPrefix + x.address
+	}
+
+	return x.address
+}
+
+// returns address of the endpoint in the multiaddr format to announce in the
+// network map, e.g. /dns4/localhost/tcp/8080/tls or /unix/tmp/cngl.sock.
+// Wildcard hosts are announced as loopback.
+func (x grpcEndpoint) announcedAddress() (string, error) {
+	if x.network == "unix" {
+		fPath, err := filepath.Abs(x.address)
+		if err != nil {
+			return "", fmt.Errorf("resolve socket path: %w", err)
+		}
+
+		return "/unix" + fPath, nil
+	}
+
+	host, port, err := net.SplitHostPort(x.address)
+	if err != nil {
+		return "", err
+	}
+
+	var res string
+
+	// wildcard hosts are not reachable, local clients dial loopback
+	ip := net.ParseIP(host)
+
+	switch {
+	case host == "", ip != nil && ip.Equal(net.IPv4zero):
+		res = "/ip4/127.0.0.1"
+	case ip != nil && ip.IsUnspecified():
+		res = "/ip6/::1"
+	case ip == nil:
+		res = "/dns4/" + host
+	case ip.To4() != nil:
+		res = "/ip4/" + ip.String()
+	default:
+		res = "/ip6/" + ip.String()
+	}
+
+	res += "/tcp/" + port
+	if x.tls != nil {
+		res += "/tls"
+	}
+
+	return res, nil
+}
+
+// listens the endpoint. Stale socket file is removed before listening.
+func (x grpcEndpoint) listen() (net.Listener, error) {
+	if x.network == "unix" {
+		err := removeStaleSocket(x.address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lis, err := net.Listen(x.network, x.address)
+	if err != nil {
+		return nil, err
+	}
+
+	if x.tls != nil {
+		lis = tls.NewListener(lis, x.tls)
+	}
+
+	return lis, nil
+}
+
+// removes socket file left by the exited process. Files of other types and
+// sockets accepting connections are kept.
+func removeStaleSocket(fPath string) error {
+	fi, err := os.Lstat(fPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("check socket file: %w", err)
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s is not a socket file", fPath)
+	}
+
+	conn, err := net.Dial("unix", fPath)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("socket %s is in use", fPath)
+	}
+
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("check socket %s: %w", fPath, err)
+	}
+
+	err = os.Remove(fPath)
+	if err != nil {
+		return fmt.Errorf("remove stale socket file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cngl
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCEndpoint_AnnouncedAddress(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		tls      bool
+		exp      string
+	}{
+		{endpoint: "localhost:8080", exp: "/dns4/localhost/tcp/8080"},
+		{endpoint: "localhost:8080", tls: true, exp: "/dns4/localhost/tcp/8080/tls"},
+		{endpoint: "10.0.0.1:8080", exp: "/ip4/10.0.0.1/tcp/8080"},
+		{endpoint: "[fe80::1]:8080", exp: "/ip6/fe80::1/tcp/8080"},
+		{endpoint: ":8080", exp: "/ip4/127.0.0.1/tcp/8080"},
+		{endpoint: "0.0.0.0:8080", exp: "/ip4/127.0.0.1/tcp/8080"},
+		{endpoint: "[::]:8080", exp: "/ip6/::1/tcp/8080"},
+		{endpoint: "unix:/tmp/cngl.sock", exp: "/unix/tmp/cngl.sock"},
+		{endpoint: "unix:///tmp/cngl.sock", exp: "/unix/tmp/cngl.sock"},
+	} {
+		e := parseEndpoint(tc.endpoint)
+		if tc.tls {
+			e.tls = new(tls.Config)
+		}
+
+		addr, err := e.announcedAddress()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.endpoint, err)
+		}
+
+		if addr != tc.exp {
+			t.Fatalf("%s: unexpected address %s instead of %s", tc.endpoint, addr, tc.exp)
+		}
+	}
+}
+
+func TestServer_UnixSocketEndpoint(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "cngl.sock")
+
+	// socket file left by the exited process
+	stale, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err = stale.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newTestServer(t, WithGRPCEndpoint("unix:"+sockPath))
+
+	err = srv.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := grpc.Dial("unix:"+sockPath, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	if code := status.Code(callUnknownMethod(conn)); code != codes.Unimplemented {
+		t.Fatalf("unexpected status over Unix socket %s", code)
+	}
+
+	var announced bool
+
+	srv.localNode.info.IterateAddresses(func(addr string) bool {
+		announced = addr == "/unix"+sockPath
+		return announced
+	})
+
+	if !announced {
+		t.Fatal("Unix socket endpoint is not announced")
+	}
+
+	// socket of the running server is kept
+	err = newTestServer(t, WithGRPCEndpoint("unix:"+sockPath)).Start()
+	if err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Fatalf("socket in use is not detected: %v", err)
+	}
+
+	if code := status.Code(callUnknownMethod(conn)); code != codes.Unimplemented {
+		t.Fatalf("unexpected status after second server failure %s", code)
+	}
+}
+
+func TestServer_StartNotSocketFile(t *testing.T) {
+	fPath := filepath.Join(t.TempDir(), "file")
+
+	err := os.WriteFile(fPath, []byte("data"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = newTestServer(t, WithGRPCEndpoint("unix:"+fPath)).Start()
+	if err == nil {
+		t.Fatal("regular file is replaced with socket")
+	}
+
+	if data, err := os.ReadFile(fPath); err != nil || string(data) != "data" {
+		t.Fatalf("regular file is changed: %v", err)
+	}
+}
+
+func TestServer_StartFailure(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = busy.Close() })
+
+	free := freeLocalAddress(t)
+	sockPath := filepath.Join(t.TempDir(), "cngl.sock")
+
+	for _, opts := range [][]Option{
+		{WithGRPCEndpoint(free), WithGRPCEndpoint("unix:" + sockPath), WithGRPCEndpoint(busy.Addr().String())},
+		{WithGRPCEndpoint(free), WithGRPCEndpoint("unix:" + sockPath), WithAdminEndpoint(busy.Addr().String())},
+		{WithGRPCEndpoint(free), WithGRPCEndpoint("unix:" + sockPath), WithAdminEndpoint(freeLocalAddress(t)),
+			WithMetricsEndpoint(busy.Addr().String())},
+	} {
+		err = newTestServer(t, opts...).Start()
+		if err == nil {
+			t.Fatal("busy endpoint is listened")
+		}
+
+		// already listened endpoints are released
+		lis, err := net.Listen("tcp", free)
+		if err != nil {
+			t.Fatalf("TCP endpoint is not released: %v", err)
+		}
+
+		_ = lis.Close()
+
+		if _, err = os.Lstat(sockPath); !os.IsNotExist(err) {
+			t.Fatalf("socket file is not removed: %v", err)
+		}
+	}
+
+}
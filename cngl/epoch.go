@@ -0,0 +1,57 @@
+package cngl
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// periodically advances epoch of the network map.
+type epochTicker struct {
+	log *zap.Logger
+
+	interval time.Duration
+
+	netMap *netMap
+
+	chStop chan struct{}
+	// closed when ticking routine exits
+	chDone chan struct{}
+}
+
+// starts ticking in a separate routine. Does nothing if interval is not set.
+func (x *epochTicker) start() {
+	if x.interval <= 0 {
+		x.log.Info("automatic epoch ticking is disabled")
+		return
+	}
+
+	x.chStop = make(chan struct{})
+	x.chDone = make(chan struct{})
+
+	go func() {
+		defer close(x.chDone)
+
+		t := time.NewTicker(x.interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-x.chStop:
+				return
+			case <-t.C:
+				x.netMap.tickEpochs(1)
+			}
+		}
+	}()
+
+	x.log.Info("epoch will be advanced periodically", zap.Duration("interval", x.interval))
+}
+
+// stops ticking and waits for the current tick to complete.
+func (x *epochTicker) stop() {
+	if x.chStop != nil {
+		close(x.chStop)
+		<-x.chDone
+	}
+}
@@ -0,0 +1,56 @@
+package cngl
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestEpochTicker(t *testing.T) {
+	var (
+		nm    netMap
+		ticks uint32
+	)
+
+	nm.log = zap.NewNop()
+	nm.init(nil)
+	nm.subscribeEpoch(func(uint64) {
+		// slow handler to catch ticks in progress on stop
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddUint32(&ticks, 1)
+	})
+
+	x := epochTicker{
+		log:      zap.NewNop(),
+		interval: time.Millisecond,
+		netMap:   &nm,
+	}
+
+	x.start()
+
+	deadline := time.Now().Add(10 * time.Second)
+
+	for nm.CurrentEpoch() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("epoch is not ticked")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	x.stop()
+
+	// all started ticks are completed on stop
+	epoch, n := nm.CurrentEpoch(), atomic.LoadUint32(&ticks)
+	if uint64(n) != epoch {
+		t.Fatalf("%d ticks are still in progress after stop", epoch-uint64(n))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if nm.CurrentEpoch() != epoch {
+		t.Fatal("epoch is ticked after stop")
+	}
+}
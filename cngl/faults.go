@@ -0,0 +1,328 @@
+package cngl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rule of the fault injection in the gRPC calls.
+type faultRule struct {
+	// service or method in Service or Service/Method format, all calls if empty
+	method string
+
+	// status to fail calls with, calls don't fail if OK
+	code codes.Code
+
+	// message of the failure status
+	message string
+
+	// delay before processing the call
+	latency time.Duration
+
+	// number of messages after which stream is aborted, 0 disables dropping
+	dropAfter uint64
+
+	// percentage of the matching calls to apply the rule to
+	percent uint64
+}
+
+// description of the fault rule in config and admin API.
+type cfgFaultRule struct {
+	Method string `json:"method"`
+
+	// gRPC status code name (e.g. UNAVAILABLE or Unavailable) or number
+	Status string `json:"status,omitempty"`
+
+	Message string `json:"message,omitempty"`
+
+	// Go duration string, e.g. 500ms
+	Latency string `json:"latency,omitempty"`
+
+	DropAfter uint64 `json:"dropAfter,omitempty"`
+
+	// 100 if not set
+	Percent *uint64 `json:"percent,omitempty"`
+}
+
+// parses gRPC status code from its name in upper snake or camel case, or number.
+func parseStatusCode(s string) (codes.Code, error) {
+	var c codes.Code
+
+	if c.UnmarshalJSON([]byte(`"`+strings.ToUpper(s)+`"`)) == nil {
+		return c, nil
+	}
+
+	for c = codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), s) {
+			return c, nil
+		}
+	}
+
+	if c.UnmarshalJSON([]byte(s)) == nil {
+		return c, nil
+	}
+
+	return 0, fmt.Errorf("invalid gRPC status code %q", s)
+}
+
+func (x cfgFaultRule) toRule() (faultRule, error) {
+	r := faultRule{
+		method:    strings.TrimPrefix(x.Method, "/"),
+		message:   x.Message,
+		dropAfter: x.DropAfter,
+		percent:   100,
+	}
+
+	var err error
+
+	if x.Status != "" {
+		r.code, err = parseStatusCode(x.Status)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if x.Latency != "" {
+		r.latency, err = time.ParseDuration(x.Latency)
+		if err != nil {
+			return r, fmt.Errorf("invalid latency: %w", err)
+		}
+	}
+
+	if x.Percent != nil {
+		if *x.Percent > 100 {
+			return r, fmt.Errorf("percentage %d is out of range [0, 100]", *x.Percent)
+		}
+
+		r.percent = *x.Percent
+	}
+
+	if r.code == codes.OK && r.latency == 0 && r.dropAfter == 0 {
+		return r, fmt.Errorf("rule for %q has no effect", x.Method)
+	}
+
+	return r, nil
+}
+
+func (x faultRule) toConfig() cfgFaultRule {
+	res := cfgFaultRule{
+		Method:    x.method,
+		Message:   x.message,
+		DropAfter: x.dropAfter,
+		Percent:   &x.percent,
+	}
+
+	if x.code != codes.OK {
+		res.Status = x.code.String()
+	}
+
+	if x.latency > 0 {
+		res.Latency = x.latency.String()
+	}
+
+	return res
+}
+
+// checks if the rule applies to the method in /Service/Method format.
+func (x faultRule) match(fullMethod string) bool {
+	name := strings.TrimPrefix(fullMethod, "/")
+
+	return x.method == "" || name == x.method || strings.HasPrefix(name, x.method+"/")
+}
+
+// returns error the call fails with.
+func (x faultRule) err() error {
+	msg := x.message
+	if msg == "" {
+		msg = "injected fault"
+	}
+
+	return status.Error(x.code, msg)
+}
+
+// injects faults into the gRPC calls according to the rules. Decisions are
+// made by the pseudo-random generator with configurable seed, so the same
+// sequence of calls is affected in the same way.
+type faultInjector struct {
+	mtx   sync.Mutex
+	rules []faultRule
+	rand  *rand.Rand
+}
+
+// resets rules and seeds random generator.
+func (x *faultInjector) reset(seed int64, rules []faultRule) {
+	x.mtx.Lock()
+	x.rules = rules
+	x.rand = rand.New(rand.NewSource(seed))
+	x.mtx.Unlock()
+}
+
+func (x *faultInjector) addRule(r faultRule) {
+	x.mtx.Lock()
+	x.rules = append(x.rules, r)
+	x.mtx.Unlock()
+}
+
+// returns copy of the current rules.
+func (x *faultInjector) listRules() []faultRule {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	res := make([]faultRule, len(x.rules))
+	copy(res, x.rules)
+
+	return res
+}
+
+// returns first rule matching the method if it should be applied to the call.
+func (x *faultInjector) pick(fullMethod string) (faultRule, bool) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	for _, r := range x.rules {
+		if !r.match(fullMethod) {
+			continue
+		}
+
+		if r.percent < 100 && uint64(x.rand.Intn(100)) >= r.percent {
+			return faultRule{}, false
+		}
+
+		return r, true
+	}
+
+	return faultRule{}, false
+}
+
+// waits for the rule latency, returns error if context is done earlier.
+func (x faultRule) delay(ctx context.Context) error {
+	if x.latency <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(x.latency)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (x *faultInjector) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r, ok := x.pick(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	if err := r.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	if r.code != codes.OK {
+		return nil, r.err()
+	}
+
+	return handler(ctx, req)
+}
+
+func (x *faultInjector) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	r, ok := x.pick(info.FullMethod)
+	if !ok {
+		return handler(srv, ss)
+	}
+
+	if err := r.delay(ss.Context()); err != nil {
+		return err
+	}
+
+	if r.dropAfter == 0 {
+		if r.code != codes.OK {
+			return r.err()
+		}
+
+		return handler(srv, ss)
+	}
+
+	if r.code == codes.OK {
+		r.code = codes.Unavailable
+	}
+
+	fs := &faultServerStream{
+		ServerStream: ss,
+		rule:         r,
+	}
+
+	err := handler(srv, fs)
+	if fs.dropped {
+		// handlers may wrap the stream error and lose its status
+		return r.err()
+	}
+
+	return err
+}
+
+// grpc.ServerStream which fails after the given number of received and sent messages.
+type faultServerStream struct {
+	grpc.ServerStream
+
+	rule faultRule
+
+	mtx     sync.Mutex
+	n       uint64
+	dropped bool
+}
+
+// counts the message, returns error if the stream should be dropped.
+func (x *faultServerStream) count() error {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	if x.n >= x.rule.dropAfter {
+		x.dropped = true
+		return x.rule.err()
+	}
+
+	x.n++
+
+	return nil
+}
+
+func (x *faultServerStream) RecvMsg(m interface{}) error {
+	if err := x.count(); err != nil {
+		return err
+	}
+
+	return x.ServerStream.RecvMsg(m)
+}
+
+func (x *faultServerStream) SendMsg(m interface{}) error {
+	if err := x.count(); err != nil {
+		return err
+	}
+
+	return x.ServerStream.SendMsg(m)
+}
+
+// decodes fault rule from JSON.
+func decodeFaultRule(data []byte) (faultRule, error) {
+	var cfg cfgFaultRule
+
+	err := json.Unmarshal(data, &cfg)
+	if err != nil {
+		return faultRule{}, fmt.Errorf("decode JSON: %w", err)
+	}
+
+	return cfg.toRule()
+}
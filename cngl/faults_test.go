@@ -0,0 +1,78 @@
+package cngl
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestFaultRule_Match(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		match  bool
+	}{
+		{"", true},
+		{"neo.fs.v2.object.ObjectService", true},
+		{"neo.fs.v2.object.ObjectService/Put", true},
+		{"neo.fs.v2.object.ObjectService/Get", false},
+		{"neo.fs.v2.object.Object", false},
+	} {
+		r := faultRule{method: tc.method}
+
+		if r.match("/neo.fs.v2.object.ObjectService/Put") != tc.match {
+			t.Errorf("rule for %q: expected match %t", tc.method, tc.match)
+		}
+	}
+}
+
+func TestFaultInjector_Seed(t *testing.T) {
+	rules := []faultRule{{code: codes.Unavailable, percent: 50}}
+
+	picks := func(seed int64) []bool {
+		var x faultInjector
+
+		x.reset(seed, rules)
+
+		res := make([]bool, 100)
+
+		for i := range res {
+			_, res[i] = x.pick("/neo.fs.v2.object.ObjectService/Put")
+		}
+
+		return res
+	}
+
+	first, second := picks(42), picks(42)
+
+	var applied int
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("call #%d is affected differently with the same seed", i)
+		}
+
+		if first[i] {
+			applied++
+		}
+	}
+
+	if applied == 0 || applied == len(first) {
+		t.Fatalf("rule with 50%% is applied to %d of %d calls", applied, len(first))
+	}
+}
+
+func TestCfgFaultRule(t *testing.T) {
+	_, err := cfgFaultRule{Method: "neo.fs.v2.object.ObjectService"}.toRule()
+	if err == nil {
+		t.Fatal("rule without effect is accepted")
+	}
+
+	r, err := cfgFaultRule{Method: "/neo.fs.v2.object.ObjectService", Status: "RESOURCE_EXHAUSTED"}.toRule()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.code != codes.ResourceExhausted || r.percent != 100 || r.method != "neo.fs.v2.object.ObjectService" {
+		t.Fatalf("unexpected rule %+v", r)
+	}
+}
@@ -0,0 +1,253 @@
+package cngl
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	objectcore "github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/eacl"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"github.com/nspcc-dev/neofs-sdk-go/version"
+	"gopkg.in/yaml.v2"
+)
+
+// fixtures loaded from the files. Applied on startup and after the state
+// is wiped.
+type fixtures struct {
+	containers []vContainer
+
+	eACL []*eacl.Table
+
+	objects []*objectcore.Object
+}
+
+// saves fixture containers and eACL tables in the state bypassing the latency.
+func (x *fixtures) applyContainers(dst *containers) {
+	for i := range x.containers {
+		dst.putWithID(x.containers[i].id, x.containers[i].cnr)
+	}
+
+	for i := range x.eACL {
+		dst.putEACLNow(x.eACL[i])
+	}
+}
+
+// stores fixture objects in the local object storage.
+func (x *fixtures) storeObjects(dst *engine.StorageEngine) error {
+	for _, obj := range x.objects {
+		err := engine.Put(dst, obj)
+		if err != nil {
+			return fmt.Errorf("store fixture object %s: %w", obj.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// container fixture in JSON/YAML file.
+type fixtureContainer struct {
+	// fixed container ID, calculated from the container if empty
+	ID string `json:"id"`
+
+	// container in NeoFS API JSON format
+	Container json.RawMessage `json:"container"`
+}
+
+// object fixture in JSON/YAML file.
+type fixtureObject struct {
+	// object in NeoFS API JSON format, payload is ignored
+	Object json.RawMessage `json:"object"`
+
+	// path to the file with object payload, relative paths
+	// are resolved against the directory of the fixture file
+	Payload string `json:"payload"`
+}
+
+// reads list of elements from the JSON or YAML (by extension) file.
+func readFixtureFile(fPath string, dst interface{}) error {
+	data, err := os.ReadFile(fPath)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(fPath)) {
+	case ".yaml", ".yml":
+		var v interface{}
+
+		err = yaml.Unmarshal(data, &v)
+		if err != nil {
+			return fmt.Errorf("decode YAML: %w", err)
+		}
+
+		data, err = json.Marshal(yamlToJSONValue(v))
+		if err != nil {
+			return fmt.Errorf("convert YAML to JSON: %w", err)
+		}
+	}
+
+	err = json.Unmarshal(data, dst)
+	if err != nil {
+		return fmt.Errorf("decode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// converts value decoded by yaml package to the one supported by encoding/json.
+func yamlToJSONValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	default:
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(vv))
+
+		for k := range vv {
+			m[fmt.Sprint(k)] = yamlToJSONValue(vv[k])
+		}
+
+		return m
+	case []interface{}:
+		for i := range vv {
+			vv[i] = yamlToJSONValue(vv[i])
+		}
+
+		return vv
+	}
+}
+
+func loadFixtureContainers(dst *[]vContainer, fPath string) error {
+	var fixtures []fixtureContainer
+
+	err := readFixtureFile(fPath, &fixtures)
+	if err != nil {
+		return err
+	}
+
+	for i := range fixtures {
+		cnr := container.New()
+
+		err = cnr.UnmarshalJSON(fixtures[i].Container)
+		if err != nil {
+			return fmt.Errorf("decode container #%d: %w", i, err)
+		}
+
+		var id *cid.ID
+
+		if fixtures[i].ID != "" {
+			id = cid.New()
+
+			err = id.Parse(fixtures[i].ID)
+			if err != nil {
+				return fmt.Errorf("decode ID of container #%d: %w", i, err)
+			}
+		} else {
+			id = container.CalculateID(cnr)
+		}
+
+		*dst = append(*dst, vContainer{
+			id:  id,
+			cnr: cnr,
+		})
+	}
+
+	return nil
+}
+
+func loadFixtureEACL(dst *[]*eacl.Table, fPath string) error {
+	var fixtures []json.RawMessage
+
+	err := readFixtureFile(fPath, &fixtures)
+	if err != nil {
+		return err
+	}
+
+	for i := range fixtures {
+		table := eacl.NewTable()
+
+		err = table.UnmarshalJSON(fixtures[i])
+		if err != nil {
+			return fmt.Errorf("decode eACL table #%d: %w", i, err)
+		}
+
+		if table.CID() == nil {
+			return fmt.Errorf("missing container ID in eACL table #%d", i)
+		}
+
+		*dst = append(*dst, table)
+	}
+
+	return nil
+}
+
+// reads objects from the fixture file and completes missing header fields.
+// Objects without ID are identified and signed by the given key.
+func loadFixtureObjects(dst *[]*objectcore.Object, fPath string, key *keys.PrivateKey, epoch uint64) error {
+	var fixtures []fixtureObject
+
+	err := readFixtureFile(fPath, &fixtures)
+	if err != nil {
+		return err
+	}
+
+	for i := range fixtures {
+		obj := object.NewRaw()
+
+		err = obj.UnmarshalJSON(fixtures[i].Object)
+		if err != nil {
+			return fmt.Errorf("decode object #%d: %w", i, err)
+		}
+
+		var payload []byte
+
+		if fixtures[i].Payload != "" {
+			payloadPath := fixtures[i].Payload
+			if !filepath.IsAbs(payloadPath) {
+				payloadPath = filepath.Join(filepath.Dir(fPath), payloadPath)
+			}
+
+			payload, err = os.ReadFile(payloadPath)
+			if err != nil {
+				return fmt.Errorf("read payload of object #%d: %w", i, err)
+			}
+		}
+
+		obj.SetPayload(payload)
+		obj.SetPayloadSize(uint64(len(payload)))
+
+		if obj.Version() == nil {
+			obj.SetVersion(version.Current())
+		}
+
+		if obj.OwnerID() == nil {
+			obj.SetOwnerID(owner.NewIDFromPublicKey((*ecdsa.PublicKey)(key.PublicKey())))
+		}
+
+		if obj.CreationEpoch() == 0 {
+			obj.SetCreationEpoch(epoch)
+		}
+
+		if obj.PayloadChecksum() == nil {
+			object.CalculateAndSetPayloadChecksum(obj)
+		}
+
+		if obj.ID() == nil {
+			err = object.SetIDWithSignature(&key.PrivateKey, obj)
+			if err != nil {
+				return fmt.Errorf("identify object #%d: %w", i, err)
+			}
+		}
+
+		*dst = append(*dst, objectcore.NewFromSDK(obj.Object()))
+	}
+
+	return nil
+}
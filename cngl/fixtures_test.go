@@ -0,0 +1,125 @@
+package cngl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	objectcore "github.com/nspcc-dev/neofs-node/pkg/core/object"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+)
+
+// writes fixture elements to the JSON file in the directory.
+func writeFixtureFile(t *testing.T, dir, name string, fixtures interface{}) string {
+	data, err := json.Marshal(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fPath := filepath.Join(dir, name)
+
+	err = os.WriteFile(fPath, data, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fPath
+}
+
+func TestLoadFixtureContainers(t *testing.T) {
+	cnr := container.New()
+
+	jCnr, err := cnr.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixedID := cidtest.ID()
+
+	fPath := writeFixtureFile(t, t.TempDir(), "containers.json", []fixtureContainer{
+		{ID: fixedID.String(), Container: jCnr},
+		{Container: jCnr},
+	})
+
+	var f fixtures
+
+	err = loadFixtureContainers(&f.containers, fPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var x containers
+	x.init()
+
+	f.applyContainers(&x)
+
+	_, err = x.Get(fixedID)
+	if err != nil {
+		t.Fatalf("container with fixed ID: %v", err)
+	}
+
+	_, err = x.Get(container.CalculateID(cnr))
+	if err != nil {
+		t.Fatalf("container with calculated ID: %v", err)
+	}
+}
+
+func TestLoadFixtureObjects(t *testing.T) {
+	dir := t.TempDir()
+	payload := []byte("Hello, world!")
+
+	err := os.WriteFile(filepath.Join(dir, "payload"), payload, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := object.NewRaw()
+	obj.SetContainerID(cidtest.ID())
+	obj.SetID(oidtest.ID())
+	obj.SetOwnerID(ownertest.ID())
+
+	jObj, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fPath := writeFixtureFile(t, dir, "objects.json", []fixtureObject{
+		{Object: jObj, Payload: "payload"},
+	})
+
+	var objs []*objectcore.Object
+
+	// key is not used since object is identified and owned
+	err = loadFixtureObjects(&objs, fPath, nil, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objs) != 1 {
+		t.Fatalf("unexpected number of objects %d", len(objs))
+	}
+
+	res := objs[0]
+
+	if !res.ID().Equal(obj.ID()) {
+		t.Fatalf("object ID is changed: %s", res.ID())
+	}
+
+	if !bytes.Equal(res.Payload(), payload) || res.PayloadSize() != uint64(len(payload)) {
+		t.Fatalf("unexpected payload %q of size %d", res.Payload(), res.PayloadSize())
+	}
+
+	if res.CreationEpoch() != 13 {
+		t.Fatalf("unexpected creation epoch %d", res.CreationEpoch())
+	}
+
+	if res.PayloadChecksum() == nil || res.Version() == nil {
+		t.Fatal("missing header fields are not completed")
+	}
+}
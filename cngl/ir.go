@@ -1,4 +1,4 @@
-package main
+package cngl
 
 type innerRing struct {
 	keys [][]byte
@@ -0,0 +1,139 @@
+package cngl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// logger parameters in the config.
+type cfgLogger struct {
+	// debug, info, warn or error
+	level string
+
+	// console or json
+	format string
+
+	// file path, stderr or stdout
+	output string
+}
+
+// builds logger with ISO8601 time encoding.
+func (x cfgLogger) build() (*zap.Logger, error) {
+	c := zap.NewProductionConfig()
+
+	err := c.Level.UnmarshalText([]byte(x.level))
+	if err != nil {
+		return nil, fmt.Errorf("invalid level: %w", err)
+	}
+
+	switch x.format {
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected console or json", x.format)
+	case "console", "json":
+		c.Encoding = x.format
+	}
+
+	c.OutputPaths = []string{x.output}
+	c.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	c.Sampling = nil
+
+	return c.Build(
+		zap.AddStacktrace(zap.NewAtomicLevelAt(zap.FatalLevel)),
+	)
+}
+
+// metadata key of the request ID set by the client, random ID is generated
+// if it is missing.
+const requestIDHeader = "x-request-id"
+
+type ctxLoggerKey struct{}
+
+// returns logger with fields of the gRPC call from the context, def otherwise.
+func loggerFromContext(ctx context.Context, def *zap.Logger) *zap.Logger {
+	if l, ok := ctx.Value(ctxLoggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+
+	return def
+}
+
+// attaches logger with method and request ID fields to the context of the
+// gRPC calls, and logs processed calls.
+type callLogger struct {
+	log *zap.Logger
+}
+
+func (x *callLogger) withCall(ctx context.Context, fullMethod string) (context.Context, *zap.Logger) {
+	var reqID string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vs := md.Get(requestIDHeader); len(vs) > 0 {
+			reqID = vs[0]
+		}
+	}
+
+	if reqID == "" {
+		reqID = uuid.NewString()
+	}
+
+	l := x.log.With(
+		zap.String("method", fullMethod),
+		zap.String("request_id", reqID),
+	)
+
+	return context.WithValue(ctx, ctxLoggerKey{}, l), l
+}
+
+func logCall(l *zap.Logger, start time.Time, err error) {
+	l.Info("gRPC call processed",
+		zap.Stringer("code", status.Code(err)),
+		zap.Duration("duration", time.Since(start)),
+		zap.Error(err),
+	)
+}
+
+func (x *callLogger) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	ctx, l := x.withCall(ctx, info.FullMethod)
+
+	resp, err := handler(ctx, req)
+
+	logCall(l, start, err)
+
+	return resp, err
+}
+
+func (x *callLogger) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	ctx, l := x.withCall(ss.Context(), info.FullMethod)
+
+	err := handler(srv, &ctxServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+
+	logCall(l, start, err)
+
+	return err
+}
+
+// grpc.ServerStream with overridden context.
+type ctxServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (x *ctxServerStream) Context() context.Context {
+	return x.ctx
+}
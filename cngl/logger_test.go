@@ -0,0 +1,49 @@
+package cngl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	netmapgrpc "github.com/nspcc-dev/neofs-api-go/v2/netmap/grpc"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCallLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	srv := newTestServer(t, WithLogger(zap.New(core)))
+
+	const method = "/neo.fs.v2.netmap.NetmapService/LocalNodeInfo"
+
+	w := httptest.NewRecorder()
+	srv.AdminHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/faults",
+		strings.NewReader(`{"method":"`+method+`","status":"UNAVAILABLE"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("add fault rule: %d %s", w.Code, w.Body)
+	}
+
+	cli := netmapgrpc.NewNetmapServiceClient(dialInMemory(t, srv))
+
+	for _, reqID := range []string{"test-1", "test-2"} {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDHeader, reqID)
+
+		_, _ = cli.LocalNodeInfo(ctx, new(netmapgrpc.LocalNodeInfoRequest))
+	}
+
+	entries := logs.FilterField(zap.String("request_id", "test-2")).All()
+	if len(entries) != 1 {
+		t.Fatalf("expected single entry of the call, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+
+	if entries[0].Message != "gRPC call processed" || fields["method"] != method || fields["code"] != "Unavailable" {
+		t.Fatalf("unexpected entry %s %v", entries[0].Message, fields)
+	}
+}
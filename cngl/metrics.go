@@ -0,0 +1,168 @@
+package cngl
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "neofs_cngl"
+
+// Prometheus metrics of the server. Each server has its own registry, so
+// several servers may run in the same process.
+type metrics struct {
+	registry *prometheus.Registry
+
+	rpcCalls *prometheus.CounterVec
+
+	rpcDuration *prometheus.HistogramVec
+
+	payloadBytes *prometheus.CounterVec
+}
+
+func newMetrics(log *zap.Logger, netMap *netMap, sessions *sessions, localObjects *engine.StorageEngine) *metrics {
+	x := &metrics{
+		registry: prometheus.NewRegistry(),
+		rpcCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "grpc",
+			Name:      "calls_total",
+			Help:      "Number of finished gRPC calls",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "grpc",
+			Name:      "call_duration_seconds",
+			Help:      "Duration of the gRPC calls",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		payloadBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "object",
+			Name:      "payload_bytes_total",
+			Help:      "Number of object payload bytes stored (put) and sent (get)",
+		}, []string{"operation"}),
+	}
+
+	x.registry.MustRegister(
+		x.rpcCalls,
+		x.rpcDuration,
+		x.payloadBytes,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "epoch",
+			Help:      "Current epoch of the network",
+		}, func() float64 {
+			return float64(netMap.CurrentEpoch())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "active_sessions",
+			Help:      "Number of active sessions",
+		}, func() float64 {
+			return float64(sessions.count())
+		}),
+		&containerCollector{
+			log:          log,
+			localObjects: localObjects,
+		},
+	)
+
+	return x
+}
+
+// returns handler serving metrics in Prometheus exposition format.
+func (x *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(x.registry, promhttp.HandlerOpts{})
+}
+
+// accounts the finished gRPC call.
+func (x *metrics) observeCall(method string, start time.Time, err error) {
+	x.rpcCalls.WithLabelValues(method, status.Code(err).String()).Inc()
+	x.rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// accounts payload bytes of the object operation: put or get.
+func (x *metrics) addPayload(op string, n int) {
+	x.payloadBytes.WithLabelValues(op).Add(float64(n))
+}
+
+func (x *metrics) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	x.observeCall(info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func (x *metrics) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	x.observeCall(info.FullMethod, start, err)
+
+	return err
+}
+
+var (
+	descContainerObjects = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "container", "objects"),
+		"Number of objects stored in the container",
+		[]string{"container"}, nil,
+	)
+
+	descContainerSize = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "container", "size_bytes"),
+		"Estimated size of the container objects",
+		[]string{"container"}, nil,
+	)
+)
+
+// collects statistics of the containers from the local object storage on scrape.
+type containerCollector struct {
+	log *zap.Logger
+
+	localObjects *engine.StorageEngine
+}
+
+func (x *containerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descContainerObjects
+	ch <- descContainerSize
+}
+
+func (x *containerCollector) Collect(ch chan<- prometheus.Metric) {
+	ids, err := engine.ListContainers(x.localObjects)
+	if err != nil {
+		x.log.Error("list containers", zap.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		strID := id.String()
+
+		addrs, err := engine.Select(x.localObjects, id, object.SearchFilters{})
+		if err != nil {
+			x.log.Error("select objects of the container", zap.String("container", strID), zap.Error(err))
+		} else {
+			ch <- prometheus.MustNewConstMetric(descContainerObjects, prometheus.GaugeValue, float64(len(addrs)), strID)
+		}
+
+		size, err := engine.ContainerSize(x.localObjects, id)
+		if err != nil {
+			x.log.Error("read size of the container", zap.String("container", strID), zap.Error(err))
+		} else {
+			ch <- prometheus.MustNewConstMetric(descContainerSize, prometheus.GaugeValue, float64(size), strID)
+		}
+	}
+}
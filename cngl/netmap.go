@@ -0,0 +1,162 @@
+package cngl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	netmapv2 "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/version"
+	"go.uber.org/zap"
+)
+
+type netMap struct {
+	log *zap.Logger
+
+	// current epoch, accessed atomically
+	epoch uint64
+
+	// serializes epoch changes
+	mtxEpoch sync.Mutex
+	// called on each epoch tick
+	epochHandlers []func(uint64)
+
+	params networkParameters
+
+	localNode *netmap.NodeInfo
+
+	mtxNetMaps sync.RWMutex
+	// network maps by epochs
+	mNetMaps map[uint64]*netmap.Netmap
+	// number of past epochs to keep network maps for, 0 means no limit
+	retention uint64
+
+	// all known nodes including offline ones
+	nodes []netmap.NodeInfo
+	// node state transitions scheduled by epochs
+	mTransitions map[uint64][]nodeTransition
+}
+
+var errNetMapNotFound = errors.New("network map not found")
+
+// initializes network map history with the network map of the current epoch
+// composed from the given nodes.
+func (x *netMap) init(nodes []netmap.NodeInfo) {
+	x.nodes = nodes
+	x.mTransitions = make(map[uint64][]nodeTransition)
+	x.mNetMaps = map[uint64]*netmap.Netmap{
+		x.CurrentEpoch(): x.composeNetMap(),
+	}
+}
+
+func (x *netMap) LocalNodeInfo(_ context.Context, _ *netmapv2.LocalNodeInfoRequest) (*netmapv2.LocalNodeInfoResponse, error) {
+	var body netmapv2.LocalNodeInfoResponseBody
+
+	body.SetVersion(version.Current().ToV2())
+	body.SetNodeInfo(x.localNode.ToV2())
+
+	var resp netmapv2.LocalNodeInfoResponse
+
+	resp.SetBody(&body)
+
+	return &resp, nil
+}
+
+func (x *netMap) NetworkInfo(_ context.Context, _ *netmapv2.NetworkInfoRequest) (*netmapv2.NetworkInfoResponse, error) {
+	var netInfo netmapv2.NetworkInfo
+
+	netInfo.SetCurrentEpoch(x.CurrentEpoch())
+	x.params.writeToV2(&netInfo)
+
+	var body netmapv2.NetworkInfoResponseBody
+
+	body.SetNetworkInfo(&netInfo)
+
+	var resp netmapv2.NetworkInfoResponse
+
+	resp.SetBody(&body)
+
+	return &resp, nil
+}
+
+func (x *netMap) GetNetMap(diff uint64) (*netmap.Netmap, error) {
+	epoch := x.CurrentEpoch()
+	if diff > epoch {
+		return nil, fmt.Errorf("%w: diff %d exceeds current epoch %d", errNetMapNotFound, diff, epoch)
+	}
+
+	return x.GetNetMapByEpoch(epoch - diff)
+}
+
+func (x *netMap) GetNetMapByEpoch(epoch uint64) (*netmap.Netmap, error) {
+	x.mtxNetMaps.RLock()
+	defer x.mtxNetMaps.RUnlock()
+
+	nm, ok := x.mNetMaps[epoch]
+	if !ok {
+		return nil, fmt.Errorf("%w: epoch %d", errNetMapNotFound, epoch)
+	}
+
+	return nm, nil
+}
+
+// saves network map of the next epoch and drops the ones beyond the retention.
+func (x *netMap) nextNetMap(epoch uint64) {
+	x.mtxNetMaps.Lock()
+
+	if x.applyTransitions(epoch) {
+		x.mNetMaps[epoch] = x.composeNetMap()
+	} else {
+		x.mNetMaps[epoch] = x.mNetMaps[epoch-1]
+	}
+
+	if x.retention > 0 && epoch > x.retention {
+		for e := range x.mNetMaps {
+			if e < epoch-x.retention {
+				delete(x.mNetMaps, e)
+			}
+		}
+	}
+
+	x.mtxNetMaps.Unlock()
+}
+
+func (x *netMap) Epoch() (uint64, error) {
+	return x.CurrentEpoch(), nil
+}
+
+func (x *netMap) CurrentEpoch() uint64 {
+	return atomic.LoadUint64(&x.epoch)
+}
+
+// registers handler of the new epochs. Handlers are called sequentially
+// in the registration order. Must be called before the epoch starts ticking.
+func (x *netMap) subscribeEpoch(f func(epoch uint64)) {
+	x.epochHandlers = append(x.epochHandlers, f)
+}
+
+// advances current epoch by n, notifies handlers about each new epoch.
+// Returns the resulting epoch.
+func (x *netMap) tickEpochs(n uint64) uint64 {
+	x.mtxEpoch.Lock()
+	defer x.mtxEpoch.Unlock()
+
+	for i := uint64(0); i < n; i++ {
+		epoch := x.CurrentEpoch() + 1
+
+		x.nextNetMap(epoch)
+
+		atomic.StoreUint64(&x.epoch, epoch)
+
+		x.log.Info("new epoch", zap.Uint64("epoch", epoch))
+
+		for _, f := range x.epochHandlers {
+			f(epoch)
+		}
+	}
+
+	return x.CurrentEpoch()
+}
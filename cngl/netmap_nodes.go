@@ -0,0 +1,110 @@
+package cngl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"go.uber.org/zap"
+)
+
+// scheduled change of the node state.
+type nodeTransition struct {
+	key []byte
+
+	state netmap.NodeState
+}
+
+// parses node state from its case-insensitive string representation.
+func parseNodeState(s string) (netmap.NodeState, error) {
+	var st netmap.NodeState
+
+	if strings.EqualFold(s, "maintenance") {
+		return 0, fmt.Errorf("node state %s is not supported by the current protocol version", s)
+	}
+
+	if !st.FromString(strings.ToUpper(s)) || st == 0 {
+		return 0, fmt.Errorf("invalid node state %q, expected ONLINE or OFFLINE", s)
+	}
+
+	return st, nil
+}
+
+// schedules node state change at the given epoch. Epoch must be in the future.
+func (x *netMap) scheduleTransition(epoch uint64, key []byte, state netmap.NodeState) error {
+	if cur := x.CurrentEpoch(); epoch <= cur {
+		return fmt.Errorf("transition epoch %d must be greater than current %d", epoch, cur)
+	}
+
+	x.mtxNetMaps.Lock()
+	defer x.mtxNetMaps.Unlock()
+
+	if x.nodeIndex(key) < 0 {
+		return fmt.Errorf("node %s is not in the network", hex.EncodeToString(key))
+	}
+
+	x.mTransitions[epoch] = append(x.mTransitions[epoch], nodeTransition{
+		key:   key,
+		state: state,
+	})
+
+	return nil
+}
+
+// returns index of the node with the given key, -1 if node is missing.
+// Must be called under lock.
+func (x *netMap) nodeIndex(key []byte) int {
+	for i := range x.nodes {
+		if bytes.Equal(x.nodes[i].PublicKey(), key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// applies transitions scheduled up to the epoch, returns true if
+// any node has changed its state. Must be called under write lock.
+func (x *netMap) applyTransitions(epoch uint64) bool {
+	var changed bool
+
+	for e, ts := range x.mTransitions {
+		if e > epoch {
+			continue
+		}
+
+		for _, t := range ts {
+			i := x.nodeIndex(t.key)
+
+			if x.nodes[i].State() != t.state {
+				x.nodes[i].SetState(t.state)
+				changed = true
+
+				x.log.Info("node state changed",
+					zap.String("node", hex.EncodeToString(t.key)), zap.Stringer("state", t.state), zap.Uint64("epoch", epoch))
+			}
+		}
+
+		delete(x.mTransitions, e)
+	}
+
+	return changed
+}
+
+// composes network map from the online nodes. Must be called under lock.
+func (x *netMap) composeNetMap() *netmap.Netmap {
+	online := make([]netmap.NodeInfo, 0, len(x.nodes))
+
+	for i := range x.nodes {
+		if x.nodes[i].State() == netmap.NodeStateOnline {
+			// value copy is enough since transitions change the state field only
+			online = append(online, x.nodes[i])
+		}
+	}
+
+	return &netmap.Netmap{
+		Nodes: netmap.NodesFromInfo(online),
+	}
+}
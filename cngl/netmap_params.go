@@ -0,0 +1,98 @@
+package cngl
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	netmapv2 "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+)
+
+// keys of the network parameters in the Netmap contract.
+const (
+	netPrmMaxObjectSize         = "MaxObjectSize"
+	netPrmBasicIncomeRate       = "BasicIncomeRate"
+	netPrmAuditFee              = "AuditFee"
+	netPrmEpochDuration         = "EpochDuration"
+	netPrmContainerFee          = "ContainerFee"
+	netPrmContainerAliasFee     = "ContainerAliasFee"
+	netPrmEigenTrustIterations  = "EigenTrustIterations"
+	netPrmEigenTrustAlpha       = "EigenTrustAlpha"
+	netPrmInnerRingCandidateFee = "InnerRingCandidateFee"
+	netPrmWithdrawFee           = "WithdrawFee"
+)
+
+// parameters of the simulated NeoFS network.
+type networkParameters struct {
+	magic uint64
+
+	msPerBlock int64
+
+	// values stored in the Netmap contract
+	epochDuration         uint64
+	maxObjectSize         uint64
+	basicIncomeRate       uint64
+	auditFee              uint64
+	containerFee          uint64
+	containerAliasFee     uint64
+	eigenTrustIterations  uint64
+	eigenTrustAlpha       float64
+	innerRingCandidateFee uint64
+	withdrawFee           uint64
+
+	// non-standard parameters as key-value pairs
+	custom [][2]string
+}
+
+// returns time interval between two sequential blocks of the side chain.
+func (x *networkParameters) blockInterval() time.Duration {
+	return time.Duration(x.msPerBlock) * time.Millisecond
+}
+
+// encodes integer the same way as Netmap contract does.
+func netPrmUint(key string, val uint64) *netmapv2.NetworkParameter {
+	var prm netmapv2.NetworkParameter
+
+	prm.SetKey([]byte(key))
+	prm.SetValue(bigint.ToBytes(new(big.Int).SetUint64(val)))
+
+	return &prm
+}
+
+func netPrmString(key string, val string) *netmapv2.NetworkParameter {
+	var prm netmapv2.NetworkParameter
+
+	prm.SetKey([]byte(key))
+	prm.SetValue([]byte(val))
+
+	return &prm
+}
+
+// writes network parameters to the NetworkInfo message.
+func (x *networkParameters) writeToV2(dst *netmapv2.NetworkInfo) {
+	prms := []*netmapv2.NetworkParameter{
+		netPrmUint(netPrmEpochDuration, x.epochDuration),
+		netPrmUint(netPrmMaxObjectSize, x.maxObjectSize),
+		netPrmUint(netPrmBasicIncomeRate, x.basicIncomeRate),
+		netPrmUint(netPrmAuditFee, x.auditFee),
+		netPrmUint(netPrmContainerFee, x.containerFee),
+		netPrmUint(netPrmContainerAliasFee, x.containerAliasFee),
+		netPrmUint(netPrmEigenTrustIterations, x.eigenTrustIterations),
+		netPrmString(netPrmEigenTrustAlpha, strconv.FormatFloat(x.eigenTrustAlpha, 'f', -1, 64)),
+		netPrmUint(netPrmInnerRingCandidateFee, x.innerRingCandidateFee),
+		netPrmUint(netPrmWithdrawFee, x.withdrawFee),
+	}
+
+	for i := range x.custom {
+		prms = append(prms, netPrmString(x.custom[i][0], x.custom[i][1]))
+	}
+
+	var netCfg netmapv2.NetworkConfig
+
+	netCfg.SetParameters(prms...)
+
+	dst.SetMagicNumber(x.magic)
+	dst.SetMsPerBlock(x.msPerBlock)
+	dst.SetNetworkConfig(&netCfg)
+}
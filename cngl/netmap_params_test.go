@@ -0,0 +1,69 @@
+package cngl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	netmapv2 "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/config"
+)
+
+func TestNetworkParameters(t *testing.T) {
+	fPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	err := os.WriteFile(fPath, []byte(`
+parameters:
+  max_object_size: 1024
+  custom:
+    - SomeKey=some=value
+    - EmptyValue=
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var st settings
+
+	st.readNetworkParameters(config.New(config.Prm{}, config.WithConfigFile(fPath)).Sub("parameters"))
+
+	prm := st.network.parameters
+
+	if prm.magic != defaultNetworkMagic || prm.epochDuration != defaultEpochDuration {
+		t.Fatal("defaults are not applied to the missing parameters")
+	}
+
+	var info netmapv2.NetworkInfo
+
+	prm.writeToV2(&info)
+
+	if info.GetMagicNumber() != defaultNetworkMagic {
+		t.Fatalf("unexpected magic %d", info.GetMagicNumber())
+	}
+
+	vals := make(map[string][]byte)
+
+	info.GetNetworkConfig().IterateParameters(func(p *netmapv2.NetworkParameter) bool {
+		vals[string(p.GetKey())] = p.GetValue()
+		return false
+	})
+
+	if v := bigint.FromBytes(vals[netPrmMaxObjectSize]); v.Uint64() != 1024 {
+		t.Fatalf("unexpected max object size %s", v)
+	}
+
+	for key, exp := range map[string]string{
+		"SomeKey":    "some=value",
+		"EmptyValue": "",
+	} {
+		v, ok := vals[key]
+		if !ok {
+			t.Fatalf("missing custom parameter %s", key)
+		}
+
+		if string(v) != exp {
+			t.Fatalf("unexpected value of the custom parameter %s: %q", key, v)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cngl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	netmapv2 "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/version"
+	"go.uber.org/zap"
+)
+
+func TestNetMap_LocalNodeInfo(t *testing.T) {
+	info := netmap.NewNodeInfo()
+	info.SetPublicKey([]byte("local node"))
+	info.SetState(netmap.NodeStateOnline)
+
+	x := netMap{log: zap.NewNop(), localNode: info}
+
+	resp, err := x.LocalNodeInfo(context.Background(), new(netmapv2.LocalNodeInfoRequest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := resp.GetBody()
+
+	if v := version.NewFromV2(body.GetVersion()); v.String() != version.Current().String() {
+		t.Fatalf("unexpected API version %s", v)
+	}
+
+	if key := body.GetNodeInfo().GetPublicKey(); !bytes.Equal(key, info.PublicKey()) {
+		t.Fatalf("unexpected node key %q", key)
+	}
+}
+
+func TestNetMap_History(t *testing.T) {
+	x := netMap{
+		log:       zap.NewNop(),
+		epoch:     10,
+		retention: 2,
+	}
+
+	x.init(nil)
+
+	nm, err := x.GetNetMap(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x.tickEpochs(3)
+
+	var res *netmap.Netmap
+
+	for _, epoch := range []uint64{11, 12, 13} {
+		res, err = x.GetNetMapByEpoch(epoch)
+		if err != nil {
+			t.Fatalf("network map of the epoch %d: %v", epoch, err)
+		}
+
+		if res != nm {
+			t.Fatalf("unexpected network map of the epoch %d", epoch)
+		}
+	}
+
+	res, err = x.GetNetMap(2)
+	if err != nil || res != nm {
+		t.Fatalf("network map of the epoch before last: %v", err)
+	}
+
+	for _, epoch := range []uint64{9, 10, 14} {
+		_, err = x.GetNetMapByEpoch(epoch)
+		if !errors.Is(err, errNetMapNotFound) {
+			t.Fatalf("unexpected error for the epoch %d: %v", epoch, err)
+		}
+	}
+
+	_, err = x.GetNetMap(14)
+	if !errors.Is(err, errNetMapNotFound) {
+		t.Fatalf("unexpected error for the diff exceeding current epoch: %v", err)
+	}
+}
+
+func TestNetMap_Transitions(t *testing.T) {
+	nodes := make([]netmap.NodeInfo, 2)
+
+	for i, key := range []string{"node1", "node2"} {
+		nodes[i].SetPublicKey([]byte(key))
+		nodes[i].SetState(netmap.NodeStateOnline)
+	}
+
+	x := netMap{log: zap.NewNop()}
+
+	x.init(nodes)
+
+	err := x.scheduleTransition(0, []byte("node2"), netmap.NodeStateOffline)
+	if err == nil {
+		t.Fatal("transition at the current epoch is scheduled")
+	}
+
+	err = x.scheduleTransition(2, []byte("unknown"), netmap.NodeStateOffline)
+	if err == nil {
+		t.Fatal("transition of the unknown node is scheduled")
+	}
+
+	err = x.scheduleTransition(2, []byte("node2"), netmap.NodeStateOffline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = x.scheduleTransition(4, []byte("node2"), netmap.NodeStateOnline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x.tickEpochs(4)
+
+	for epoch, exp := range []int{2, 2, 1, 1, 2} {
+		nm, err := x.GetNetMapByEpoch(uint64(epoch))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(nm.Nodes) != exp {
+			t.Fatalf("unexpected number of nodes %d at epoch %d", len(nm.Nodes), epoch)
+		}
+	}
+}
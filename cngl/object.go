@@ -1,4 +1,4 @@
-package main
+package cngl
 
 import (
 	"bytes"
@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"hash"
 	"io"
-	"log"
-	"math"
 
 	objectV2 "github.com/nspcc-dev/neofs-api-go/v2/object"
 	"github.com/nspcc-dev/neofs-api-go/v2/refs"
@@ -18,7 +16,6 @@ import (
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
 	objectSvc "github.com/nspcc-dev/neofs-node/pkg/services/object"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
-	"github.com/nspcc-dev/neofs-node/pkg/services/session/storage"
 	"github.com/nspcc-dev/neofs-node/pkg/util"
 	"github.com/nspcc-dev/neofs-sdk-go/checksum"
 	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
@@ -27,28 +24,37 @@ import (
 	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
 	"github.com/nspcc-dev/neofs-sdk-go/session"
 	"github.com/nspcc-dev/tzhash/tz"
+	"go.uber.org/zap"
 )
 
 type serviceServerObject struct {
-	sessionTokens *storage.TokenStore
+	log *zap.Logger
+
+	sessions *sessions
 
 	containers *containers
 
 	localObjects *engine.StorageEngine
 
 	netState netmap.State
+
+	// network limit of the object payload size
+	maxObjectSize uint64
+
+	metrics *metrics
 }
 
 // copied from neofs-node
 
-func (x *serviceServerObject) DeleteObjects(ts *address.Address, as ...*address.Address) {
+// DeleteObjects inhumes objects removed by the tombstone stored in the stream.
+func (x *streamObjectPut) DeleteObjects(ts *address.Address, as ...*address.Address) {
 	prm := new(engine.InhumePrm)
 
 	for _, a := range as {
 		prm.WithTarget(ts, a)
 
-		if _, err := x.localObjects.Inhume(prm); err != nil {
-			log.Println("could not delete object", a, err)
+		if _, err := x.svc.localObjects.Inhume(prm); err != nil {
+			x.log.Error("could not delete object", zap.Stringer("address", a), zap.Error(err))
 		}
 	}
 }
@@ -99,6 +105,10 @@ func (x *serviceServerObject) Get(req *objectV2.GetRequest, stream objectSvc.Get
 
 		payload := v2obj.GetPayload()
 
+		defer func() {
+			x.metrics.addPayload("get", len(v2obj.GetPayload())-len(payload))
+		}()
+
 		for ln := len(payload); ln > 0; ln = len(payload) {
 			if ln > 4096 {
 				ln = 4086
@@ -121,6 +131,9 @@ func (x *serviceServerObject) Get(req *objectV2.GetRequest, stream objectSvc.Get
 }
 
 type streamObjectPut struct {
+	// logger of the call
+	log *zap.Logger
+
 	withSession  bool
 	tokenSession session.Token
 
@@ -161,6 +174,8 @@ func (x *streamObjectPut) CloseAndRecv() (*objectV2.PutResponse, error) {
 		return nil, err
 	}
 
+	x.svc.metrics.addPayload("put", len(x.obj.GetPayload()))
+
 	var bodyResp objectV2.PutResponseBody
 	bodyResp.SetObjectID(x.id.ToV2())
 
@@ -317,16 +332,16 @@ func (x *streamObjectPut) finalizeAndSave() error {
 			return errors.New("missing owner in raw object")
 		}
 
-		tokenPriv := x.svc.sessionTokens.Get(idOwner, x.tokenSession.ID())
-		if tokenPriv == nil {
+		tokenPriv, ok := x.svc.sessions.get(idOwner, x.tokenSession.ID())
+		if !ok {
 			return errors.New("private session not found")
-		} else if tokenPriv.ExpiredAt() <= x.svc.netState.CurrentEpoch() {
+		} else if tokenPriv.exp <= x.svc.netState.CurrentEpoch() {
 			return errors.New("expired session")
 		}
 
-		tgt = transformer.NewPayloadSizeLimiter(math.MaxUint64, func() transformer.ObjectTarget {
+		tgt = transformer.NewPayloadSizeLimiter(x.svc.maxObjectSize, func() transformer.ObjectTarget {
 			return transformer.NewFormatTarget(&transformer.FormatterParams{
-				Key:          tokenPriv.SessionKey(),
+				Key:          tokenPriv.key,
 				NextTarget:   tgtLocal,
 				SessionToken: &x.tokenSession,
 				NetworkState: x.svc.netState,
@@ -344,10 +359,11 @@ func (x *streamObjectPut) finalizeAndSave() error {
 		}
 	} else {
 		tgt = &validatingTarget{
-			nextTarget: tgtLocal,
+			nextTarget:   tgtLocal,
+			maxPayloadSz: x.svc.maxObjectSize,
 			fmt: objectcore.NewFormatValidator(
 				objectcore.WithNetState(x.svc.netState),
-				objectcore.WithDeleteHandler(x.svc),
+				objectcore.WithDeleteHandler(x),
 			),
 		}
 
@@ -369,11 +385,17 @@ func (x *streamObjectPut) finalizeAndSave() error {
 
 	x.id = *ids.SelfID()
 
+	x.log.Debug("object stored",
+		zap.Stringer("container", obj.ContainerID()),
+		zap.Stringer("object", &x.id),
+	)
+
 	return nil
 }
 
-func (x *serviceServerObject) Put(_ context.Context) (objectSvc.PutObjectStream, error) {
+func (x *serviceServerObject) Put(ctx context.Context) (objectSvc.PutObjectStream, error) {
 	return &streamObjectPut{
+		log: loggerFromContext(ctx, x.log),
 		svc: x,
 	}, nil
 }
@@ -467,6 +489,10 @@ func (x *serviceServerObject) GetRange(req *objectV2.GetRangeRequest, stream obj
 
 		payload := res.Object().Payload()
 
+		defer func(ln int) {
+			x.metrics.addPayload("get", ln-len(payload))
+		}(len(payload))
+
 		var partChunk objectV2.GetRangePartChunk
 
 		var body objectV2.GetRangeResponseBody
@@ -0,0 +1,672 @@
+package cngl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	accountingapigrpc "github.com/nspcc-dev/neofs-api-go/v2/accounting/grpc"
+	containerapigrpc "github.com/nspcc-dev/neofs-api-go/v2/container/grpc"
+	netmapapigrpc "github.com/nspcc-dev/neofs-api-go/v2/netmap/grpc"
+	objectapigrpc "github.com/nspcc-dev/neofs-api-go/v2/object/grpc"
+	sessionapigrpc "github.com/nspcc-dev/neofs-api-go/v2/session/grpc"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/blobstor"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	meta "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/metabase"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/shard"
+	accountinggrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/accounting/grpc"
+	containergrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/container/grpc"
+	netmapgrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/netmap/grpc"
+	objectgrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/object/grpc"
+	sessiongrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/session/grpc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/accounting"
+	"github.com/nspcc-dev/neofs-node/pkg/services/container"
+	container2 "github.com/nspcc-dev/neofs-node/pkg/services/container/morph"
+	svcnetmap "github.com/nspcc-dev/neofs-node/pkg/services/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/session"
+	"github.com/nspcc-dev/neofs-node/pkg/util"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// prepares logger of the Server according to the settings. Called before
+// prepare to log the preparation.
+func (x *Server) prepareLogger(ctx *settings) {
+	if ctx.logger.log != nil {
+		x.log = ctx.logger.log
+		return
+	}
+
+	l, err := ctx.logger.cfg.build()
+	if err != nil {
+		panic(fmt.Errorf("build logger: %w", err))
+	}
+
+	x.log = l
+}
+
+// prepares all components of the Server according to the settings.
+func (x *Server) prepare(ctx *settings) {
+	x.grpc.trafficLog.log = x.log
+	x.network.netMap.state.log = x.log
+	x.network.netMap.ticker.log = x.log
+	x.network.accounting.state.log = x.log
+	x.network.containers.state.log = x.log
+	x.storage.sessions.log = x.log
+	x.grpc.trafficLog.path = ctx.grpc.trafficLog.path
+	x.grpc.trafficLog.maxSize = ctx.grpc.trafficLog.maxSize
+	x.grpc.trafficLog.maxBackups = ctx.grpc.trafficLog.maxBackups
+	x.grpc.trafficLog.lossless = ctx.grpc.trafficLog.lossless
+	x.admin.listenAddress = ctx.admin.listenAddress
+	x.metrics.listenAddress = ctx.metrics.listenAddress
+	x.network.netMap.state.epoch = ctx.network.netMap.epoch
+	x.network.netMap.state.params = ctx.network.parameters
+	x.network.netMap.state.retention = ctx.network.netMap.retention
+	x.network.accounting.state.precision = ctx.network.accounting.precision
+	x.network.accounting.state.defaultValue = ctx.network.accounting.defaultBalance
+	x.network.accounting.state.filepath = ctx.network.accounting.ledgerFilepath
+	x.network.containers.state.lenientPlacement = ctx.network.containers.lenientPlacement
+	x.storage.sessions.maxPerOwner = ctx.storage.maxSessionsPerOwner
+
+	x.prepareBasics(ctx)
+	x.prepareEndpoints(ctx)
+	x.prepareLocalNode(ctx)
+	x.prepareNetwork(ctx)
+	x.prepareFixtures(ctx)
+	x.prepareMetrics(ctx)
+	x.prepareAPI(ctx)
+	x.prepareGRPC(ctx)
+	x.prepareAdmin(ctx)
+}
+
+func (x *Server) prepareBasics(ctx *settings) {
+	if ctx.basics.key != nil {
+		x.basics.key = *ctx.basics.key
+		return
+	}
+
+	if ctx.basics.keyFilepath == "" {
+		k, err := keys.NewPrivateKey()
+		if err != nil {
+			failPrepare("generate private key: %w", err)
+		}
+
+		x.basics.key = *k
+
+		return
+	}
+
+	binKey, err := os.ReadFile(ctx.basics.keyFilepath)
+	if err != nil {
+		failPrepare("read private key file: %w", err)
+	}
+
+	k, err := keys.NewPrivateKeyFromBytes(binKey)
+	if err != nil {
+		failPrepare("decode private key: %w", err)
+	}
+
+	x.basics.key = *k
+}
+
+func (x *Server) prepareEndpoints(ctx *settings) {
+	x.grpc.endpoints = make([]grpcEndpoint, len(ctx.grpc.endpoints))
+
+	for i, cfg := range ctx.grpc.endpoints {
+		x.grpc.endpoints[i] = parseEndpoint(cfg.address)
+		x.grpc.endpoints[i].tls = cfg.tlsConfig
+
+		if x.grpc.endpoints[i].tls == nil && cfg.tls.enabled {
+			var err error
+
+			x.grpc.endpoints[i].tls, err = cfg.tls.toConfig(cfg.address)
+			if err != nil {
+				failPrepare("TLS of the gRPC endpoint %s: %v", cfg.address, err)
+			}
+
+			if cfg.tls.selfSigned {
+				x.log.Info("gRPC endpoint is served with self-signed TLS certificate",
+					zap.String("endpoint", cfg.address))
+			}
+		}
+	}
+}
+
+func (x *Server) prepareLocalNode(ctx *settings) {
+	switch {
+	case ctx.localNode.info != nil:
+		x.localNode.info = *ctx.localNode.info
+	case ctx.localNode.infoFilepath != "":
+		jData, err := os.ReadFile(ctx.localNode.infoFilepath)
+		if err != nil {
+			failPrepare("read file with local node info: %w", err)
+		}
+
+		err = json.Unmarshal(jData, &x.localNode.info)
+		if err != nil {
+			failPrepare("decode local node info JSON: %w", err)
+		}
+	}
+
+	if x.localNode.info.State() == 0 {
+		x.localNode.info.SetState(netmap.NodeStateOnline)
+
+		x.log.Info("missing local node state in JSON, set to online")
+	}
+
+	if len(x.localNode.info.PublicKey()) == 0 {
+		x.localNode.info.SetPublicKey(x.basics.key.PublicKey().Bytes())
+
+		x.log.Info("missing local node public key in JSON, set to local key")
+	}
+
+	addrs := make([]string, 0, x.localNode.info.NumberOfAddresses()+len(x.grpc.endpoints))
+	mAddrs := make(map[string]struct{}, cap(addrs))
+
+	netmap.IterateAllAddresses(&x.localNode.info, func(addr string) {
+		addrs = append(addrs, addr)
+		mAddrs[addr] = struct{}{}
+	})
+
+	// endpoints are announced in addition to the addresses from JSON
+	for _, e := range x.grpc.endpoints {
+		addr, err := e.announcedAddress()
+		if err != nil {
+			failPrepare("address of the gRPC endpoint %s: %v", e, err)
+		}
+
+		if _, ok := mAddrs[addr]; !ok {
+			addrs = append(addrs, addr)
+			mAddrs[addr] = struct{}{}
+		}
+	}
+
+	x.localNode.info.SetAddresses(addrs...)
+}
+
+func (x *Server) prepareNetwork(ctx *settings) {
+	x.prepareInnerRing(ctx)
+	x.prepareNetMap(ctx)
+	x.prepareContainers(ctx)
+	x.prepareAccounting(ctx)
+}
+
+func (x *Server) prepareInnerRing(ctx *settings) {
+	x.network.ir.state.keys = make([][]byte, len(ctx.network.ir.keysStr))
+
+	var err error
+
+	for i := range ctx.network.ir.keysStr {
+		x.network.ir.state.keys[i], err = hex.DecodeString(ctx.network.ir.keysStr[i])
+		if err != nil {
+			failPrepare("decode IR key: %w", err)
+		}
+	}
+}
+
+func (x *Server) prepareNetMap(ctx *settings) {
+	x.network.netMap.state.localNode = &x.localNode.info
+	nodes := []netmap.NodeInfo{x.localNode.info}
+
+	if ctx.network.netMap.nodesFilepath != "" {
+		jData, err := os.ReadFile(ctx.network.netMap.nodesFilepath)
+		if err != nil {
+			failPrepare("read file with network map nodes: %w", err)
+		}
+
+		var fileNodes []netmap.NodeInfo
+
+		err = json.Unmarshal(jData, &fileNodes)
+		if err != nil {
+			failPrepare("decode network map nodes JSON: %w", err)
+		}
+
+		nodes = append(nodes, fileNodes...)
+	}
+
+	for i := range ctx.network.netMap.nodes {
+		nodes = append(nodes, nodeInfoFromConfig(ctx.network.netMap.nodes[i]))
+	}
+
+	nodes = append(nodes, ctx.network.netMap.nodeInfos...)
+
+	mKeys := make(map[string]struct{}, len(nodes))
+
+	for i := range nodes {
+		if len(nodes[i].PublicKey()) == 0 {
+			failPrepare("missing public key of the network map node #%d", i)
+		}
+
+		strKey := hex.EncodeToString(nodes[i].PublicKey())
+		if _, ok := mKeys[strKey]; ok {
+			failPrepare("duplicated network map node %s", strKey)
+		}
+
+		mKeys[strKey] = struct{}{}
+
+		if nodes[i].State() == 0 {
+			nodes[i].SetState(netmap.NodeStateOnline)
+		}
+	}
+
+	x.log.Info("network map is initialized", zap.Int("nodes", len(nodes)))
+
+	x.network.netMap.state.init(nodes)
+
+	for _, ev := range ctx.network.netMap.events {
+		key, err := hex.DecodeString(ev.key)
+		if err != nil {
+			failPrepare("decode node key of the scheduled network map event: %w", err)
+		}
+
+		state, err := parseNodeState(ev.state)
+		if err != nil {
+			failPrepare("scheduled network map event: %w", err)
+		}
+
+		err = x.network.netMap.state.scheduleTransition(ev.epoch, key, state)
+		if err != nil {
+			failPrepare("schedule network map event: %w", err)
+		}
+	}
+
+	x.network.netMap.ticker.netMap = &x.network.netMap.state
+
+	if ctx.network.netMap.autoTick {
+		prm := &x.network.netMap.state.params
+		x.network.netMap.ticker.interval = time.Duration(prm.epochDuration) * prm.blockInterval()
+
+		if x.network.netMap.ticker.interval <= 0 {
+			failPrepare("automatic epoch ticking requires positive epoch duration, got %d blocks of %dms",
+				prm.epochDuration, prm.msPerBlock)
+		}
+	}
+}
+
+func nodeInfoFromConfig(cfg cfgNode) netmap.NodeInfo {
+	key, err := hex.DecodeString(cfg.key)
+	if err != nil {
+		failPrepare("decode public key of the network map node: %w", err)
+	}
+
+	attrs := make([]*netmap.NodeAttribute, len(cfg.attributes))
+
+	for i := range cfg.attributes {
+		j := strings.IndexByte(cfg.attributes[i], ':')
+		if j < 0 {
+			failPrepare("invalid attribute %q of the network map node %s, expected KEY:VALUE", cfg.attributes[i], cfg.key)
+		}
+
+		attrs[i] = netmap.NewNodeAttribute()
+		attrs[i].SetKey(cfg.attributes[i][:j])
+		attrs[i].SetValue(cfg.attributes[i][j+1:])
+	}
+
+	var info netmap.NodeInfo
+
+	info.SetPublicKey(key)
+	info.SetAddresses(cfg.addresses...)
+	info.SetAttributes(attrs...)
+	info.SetState(netmap.NodeStateOnline)
+
+	return info
+}
+
+func (x *Server) prepareContainers(ctx *settings) {
+	x.network.containers.state.init()
+	x.network.containers.state.netMap = &x.network.netMap.state
+
+	if ctx.network.containers.latencyBlocks > 0 {
+		x.network.containers.state.latency = time.Duration(ctx.network.containers.latencyBlocks) * x.network.netMap.state.params.blockInterval()
+	} else {
+		x.network.containers.state.latency = ctx.network.containers.latencyDuration
+	}
+
+	if x.network.containers.state.latency > 0 {
+		x.log.Info("container changes will be applied with latency",
+			zap.Duration("latency", x.network.containers.state.latency))
+	}
+}
+
+func (x *Server) prepareAccounting(ctx *settings) {
+	state := &x.network.accounting.state
+
+	switch state.precision {
+	default:
+		failPrepare("unsupported balance precision %d, expected %d or %d",
+			state.precision, balancePrecisionGAS, balancePrecisionBalance)
+	case balancePrecisionGAS, balancePrecisionBalance:
+	}
+
+	state.init()
+
+	for _, kv := range ctx.network.accounting.balances {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			failPrepare("invalid balance %q, expected OWNER=AMOUNT", kv)
+		}
+
+		id := owner.NewID()
+
+		err := id.Parse(kv[:i])
+		if err != nil {
+			failPrepare("decode owner ID of the balance %q: %w", kv, err)
+		}
+
+		v, err := strconv.ParseInt(kv[i+1:], 10, 64)
+		if err != nil {
+			failPrepare("decode amount of the balance %q: %w", kv, err)
+		}
+
+		state.set(id, v)
+	}
+
+	state.snapshot()
+
+	err := state.load()
+	if err != nil {
+		failPrepare("load balance ledger from %s: %w", state.filepath, err)
+	}
+
+	prm := &x.network.netMap.state.params
+
+	x.network.containers.state.balances = state
+	x.network.containers.state.fee = state.fromFixed8(prm.containerFee)
+
+	nodeKey, err := keys.NewPublicKeyFromBytes(x.localNode.info.PublicKey(), elliptic.P256())
+	if err != nil {
+		failPrepare("decode public key of the local node: %w", err)
+	}
+
+	b := &billing{
+		log:          x.log,
+		balances:     state,
+		containers:   &x.network.containers.state,
+		localObjects: &x.storage.localObjects,
+		rate:         prm.basicIncomeRate,
+		nodeOwner:    owner.NewIDFromPublicKey((*ecdsa.PublicKey)(nodeKey)),
+	}
+
+	x.network.netMap.state.subscribeEpoch(b.chargeStorage)
+}
+
+func (x *Server) prepareFixtures(ctx *settings) {
+	var err error
+
+	for _, fPath := range ctx.fixtures.containersFilepaths {
+		err = loadFixtureContainers(&x.fixtures.containers, fPath)
+		if err != nil {
+			failPrepare("load container fixtures from %s: %w", fPath, err)
+		}
+
+		x.log.Info("container fixtures loaded", zap.String("path", fPath))
+	}
+
+	for _, fPath := range ctx.fixtures.eACLFilepaths {
+		err = loadFixtureEACL(&x.fixtures.eACL, fPath)
+		if err != nil {
+			failPrepare("load eACL fixtures from %s: %w", fPath, err)
+		}
+
+		x.log.Info("eACL fixtures loaded", zap.String("path", fPath))
+	}
+
+	for _, fPath := range ctx.fixtures.objectsFilepaths {
+		err = loadFixtureObjects(&x.fixtures.objects, fPath, &x.basics.key, x.network.netMap.state.epoch)
+		if err != nil {
+			failPrepare("load object fixtures from %s: %w", fPath, err)
+		}
+
+		x.log.Info("object fixtures loaded", zap.String("path", fPath))
+	}
+
+	x.fixtures.applyContainers(&x.network.containers.state)
+}
+
+func (x *Server) prepareMetrics(_ *settings) {
+	x.metrics.state = newMetrics(x.log, &x.network.netMap.state, &x.storage.sessions, &x.storage.localObjects)
+}
+
+func (x *Server) prepareAPI(ctx *settings) {
+	x.prepareAPIObject(ctx)
+	x.prepareAPISession(ctx)
+	x.prepareAPIContainer(ctx)
+	x.prepareAPIAccounting(ctx)
+	x.prepareAPINetmap(ctx)
+	x.prepareStorage(ctx)
+}
+
+func (x *Server) prepareAPIObject(_ *settings) {
+	x.api.object.server = &serviceServerObject{
+		log:           x.log,
+		sessions:      &x.storage.sessions,
+		containers:    &x.network.containers.state,
+		localObjects:  &x.storage.localObjects,
+		netState:      &x.network.netMap.state,
+		metrics:       x.metrics.state,
+		maxObjectSize: x.network.netMap.state.params.maxObjectSize,
+	}
+
+	// x.api.object.server = acl.New(
+	//	acl.WithNextService(x.api.object.server),
+	//	acl.WithSenderClassifier(
+	//		acl.NewSenderClassifier(zap.NewNop(), &x.network.ir.state, &x.network.netMap.state),
+	//	),
+	//	acl.WithContainerSource(&x.network.containers.state),
+	//	acl.WithEACLSource(&x.network.containers.state),
+	//	acl.WithNetmapState(&x.network.netMap.state),
+	// )
+
+	x.api.object.server = object.NewSignService(&x.basics.key.PrivateKey, x.api.object.server)
+}
+
+func (x *Server) prepareAPIContainer(_ *settings) {
+	x.api.container.server = container.NewExecutionService(
+		container2.NewExecutor(&x.network.containers.state, &x.network.containers.state),
+	)
+
+	x.api.container.server = container.NewSignService(&x.basics.key.PrivateKey, x.api.container.server)
+}
+
+func (x *Server) prepareAPISession(_ *settings) {
+	x.api.session.server = session.NewExecutionService(&x.storage.sessions)
+	x.api.session.server = session.NewSignService(&x.basics.key.PrivateKey, x.api.session.server)
+}
+
+func (x *Server) prepareAPIAccounting(_ *settings) {
+	x.api.accounting.server = &serviceServerAccounting{
+		balances: &x.network.accounting.state,
+	}
+	x.api.accounting.server = accounting.NewSignService(&x.basics.key.PrivateKey, x.api.accounting.server)
+}
+
+func (x *Server) prepareAPINetmap(_ *settings) {
+	x.api.netmap.server = &x.network.netMap.state
+	x.api.netmap.server = svcnetmap.NewSignService(&x.basics.key.PrivateKey, x.api.netmap.server)
+}
+
+func (x *Server) prepareGRPC(ctx *settings) {
+	calls := &callLogger{
+		log: x.log,
+	}
+
+	// call logger is first to provide request-scoped logger to the others,
+	// metrics are next to account calls failed by the other interceptors
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(calls.unaryInterceptor, x.metrics.state.unaryInterceptor),
+		grpc.ChainStreamInterceptor(calls.streamInterceptor, x.metrics.state.streamInterceptor),
+	}
+
+	if ctx.grpc.dump.enabled {
+		dumper := &requestDumper{
+			log:     x.log,
+			filters: ctx.grpc.dump.filters,
+		}
+
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(dumper.unaryInterceptor),
+			grpc.ChainStreamInterceptor(dumper.streamInterceptor),
+		)
+
+		x.log.Info("gRPC requests will be dumped to the log")
+	}
+
+	if x.grpc.trafficLog.path != "" {
+		err := x.grpc.trafficLog.open(ctx.grpc.trafficLog.buffer)
+		if err != nil {
+			failPrepare("open traffic log: %v", err)
+		}
+
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(x.grpc.trafficLog.unaryInterceptor),
+			grpc.ChainStreamInterceptor(x.grpc.trafficLog.streamInterceptor),
+		)
+
+		x.log.Info("gRPC traffic will be logged", zap.String("path", x.grpc.trafficLog.path))
+	}
+
+	rules := make([]faultRule, len(ctx.grpc.faults.rules))
+
+	for i := range ctx.grpc.faults.rules {
+		var err error
+
+		rules[i], err = ctx.grpc.faults.rules[i].toRule()
+		if err != nil {
+			failPrepare("fault rule #%d: %v", i, err)
+		}
+	}
+
+	x.grpc.faults.reset(ctx.grpc.faults.seed, rules)
+
+	// always installed to manage rules via admin API
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(x.grpc.faults.unaryInterceptor),
+		grpc.ChainStreamInterceptor(x.grpc.faults.streamInterceptor),
+	)
+
+	if len(rules) > 0 {
+		x.log.Info("fault rules are applied to gRPC calls", zap.Int("rules", len(rules)))
+	}
+
+	if ctx.grpc.mockFilepath != "" {
+		mock := &mockTraffic{
+			key: &x.basics.key.PrivateKey,
+		}
+
+		err := mock.load(ctx.grpc.mockFilepath)
+		if err != nil {
+			failPrepare("load recorded gRPC calls from %s: %v", ctx.grpc.mockFilepath, err)
+		}
+
+		// placed after the traffic log to record mocked responses as well
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(mock.unaryInterceptor),
+			grpc.ChainStreamInterceptor(mock.streamInterceptor),
+		)
+
+		x.log.Info("gRPC calls will be served from the records", zap.String("path", ctx.grpc.mockFilepath))
+	}
+
+	x.grpc.server = grpc.NewServer(opts...)
+
+	objectapigrpc.RegisterObjectServiceServer(x.grpc.server, objectgrpc.New(x.api.object.server))
+	sessionapigrpc.RegisterSessionServiceServer(x.grpc.server, sessiongrpc.New(x.api.session.server))
+	containerapigrpc.RegisterContainerServiceServer(x.grpc.server, containergrpc.New(x.api.container.server))
+	accountingapigrpc.RegisterAccountingServiceServer(x.grpc.server, accountinggrpc.New(x.api.accounting.server))
+	netmapapigrpc.RegisterNetmapServiceServer(x.grpc.server, netmapgrpc.New(x.api.netmap.server))
+}
+
+func (x *Server) prepareAdmin(_ *settings) {
+	srv := &adminServer{
+		log:         x.log,
+		containers:  &x.network.containers.state,
+		netMap:      &x.network.netMap.state,
+		balances:    &x.network.accounting.state,
+		withdrawFee: x.network.accounting.state.fromFixed8(x.network.netMap.state.params.withdrawFee),
+		sessions:    &x.storage.sessions,
+		faults:      &x.grpc.faults,
+		fixtures:    &x.fixtures,
+
+		localObjects: &x.storage.localObjects,
+		gcEvents:     &x.storage.gcEvents,
+		storagePath:  x.storage.path,
+	}
+
+	x.admin.handler = srv.handler()
+}
+
+func (x *Server) prepareStorage(ctx *settings) {
+	var err error
+
+	l := x.log.Named("storage")
+
+	x.storage.path = ctx.storage.localObjectsFilepath
+
+	if x.storage.path == "" {
+		x.storage.path, err = os.MkdirTemp("", "neofs-cngl-")
+		if err != nil {
+			failPrepare("create temporary local object storage directory: %v", err)
+		}
+
+		x.storage.temporary = true
+
+		x.log.Info("objects are stored in the temporary directory", zap.String("path", x.storage.path))
+	} else {
+		err = util.MkdirAllX(x.storage.path, 0644)
+		if err != nil {
+			failPrepare("create local object storage path: %v", err)
+		}
+	}
+
+	x.storage.localObjects = *engine.New(
+		engine.WithLogger(l),
+	)
+
+	x.network.netMap.state.subscribeEpoch(x.storage.gcEvents.newEpoch)
+
+	_, err = x.storage.localObjects.AddShard(
+		shard.WithWriteCache(false),
+		shard.WithGCWorkerPoolInitializer(func(int) util.WorkerPool {
+			return util.NewPseudoWorkerPool()
+		}),
+		shard.WithGCEventChannelInitializer(x.storage.gcEvents.init),
+		shard.WithBlobStorOptions(
+			blobstor.WithLogger(l),
+			blobstor.WithBlobovniczaShallowWidth(2),
+			blobstor.WithBlobovniczaShallowDepth(1),
+			blobstor.WithRootPath(filepath.Join(x.storage.path, storageBlobDir)),
+		),
+		shard.WithMetaBaseOptions(
+			meta.WithLogger(l),
+			meta.WithPath(filepath.Join(x.storage.path, storageMetaDir)),
+		),
+	)
+	if err != nil {
+		failPrepare("add shard: %v", err)
+	}
+
+	x.storage.sessions.init()
+
+	if ctx.storage.sessionsFilepath != "" {
+		err = x.storage.sessions.open(ctx.storage.sessionsFilepath, &x.basics.key)
+		if err != nil {
+			failPrepare("open session storage: %v", err)
+		}
+
+		x.log.Info("sessions are persisted", zap.String("path", ctx.storage.sessionsFilepath))
+	}
+
+	x.storage.sessions.removeExpired(x.network.netMap.state.CurrentEpoch())
+	x.network.netMap.state.subscribeEpoch(x.storage.sessions.removeExpired)
+}
@@ -0,0 +1,70 @@
+package cngl
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"go.uber.org/zap"
+)
+
+// calls f and returns message of the preparation failure, empty if f returns normally.
+func catchPrepareError(f func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = r.(prepareError).err.Error()
+		}
+	}()
+
+	f()
+
+	return ""
+}
+
+func TestServer_PrepareNetMap(t *testing.T) {
+	localKey := []byte("local node")
+
+	newServer := func(nodes ...cfgNode) (*Server, *settings) {
+		x := &Server{log: zap.NewNop()}
+		x.localNode.info.SetPublicKey(localKey)
+
+		s := defaultSettings()
+		s.network.netMap.nodes = nodes
+
+		return x, &s
+	}
+
+	x, ctx := newServer(cfgNode{
+		key:        hex.EncodeToString([]byte("other node")),
+		attributes: []string{"Location:Moscow"},
+	})
+
+	if msg := catchPrepareError(func() { x.prepareNetMap(ctx) }); msg != "" {
+		t.Fatalf("distinct nodes are rejected: %s", msg)
+	}
+
+	nm, err := x.network.netMap.state.GetNetMap(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nm.Nodes) != 2 {
+		t.Fatalf("unexpected number of nodes %d", len(nm.Nodes))
+	}
+
+	for i := range nm.Nodes {
+		if nm.Nodes[i].State() != netmap.NodeStateOnline {
+			t.Fatalf("node #%d is not online", i)
+		}
+	}
+
+	x, ctx = newServer(cfgNode{
+		key: hex.EncodeToString(localKey),
+	})
+
+	msg := catchPrepareError(func() { x.prepareNetMap(ctx) })
+	if !strings.Contains(msg, "duplicated network map node") {
+		t.Fatalf("duplicated node key is not rejected: %q", msg)
+	}
+}
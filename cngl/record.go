@@ -0,0 +1,411 @@
+package cngl
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-api-go/v2/accounting"
+	containerV2 "github.com/nspcc-dev/neofs-api-go/v2/container"
+	netmapv2 "github.com/nspcc-dev/neofs-api-go/v2/netmap"
+	objectV2 "github.com/nspcc-dev/neofs-api-go/v2/object"
+	"github.com/nspcc-dev/neofs-api-go/v2/rpc/message"
+	"github.com/nspcc-dev/neofs-api-go/v2/session"
+	"github.com/nspcc-dev/neofs-api-go/v2/signature"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// gRPC method resolved from the registered protobuf descriptors.
+type rpcMethod struct {
+	desc protoreflect.MethodDescriptor
+
+	in, out protoreflect.MessageType
+}
+
+// resolves method by full name (/Service/Method) among registered NeoFS API services.
+func resolveMethod(fullMethod string) (rpcMethod, error) {
+	name := strings.TrimPrefix(fullMethod, "/")
+
+	i := strings.LastIndexByte(name, '/')
+	if i < 0 {
+		return rpcMethod{}, fmt.Errorf("invalid method name %s", fullMethod)
+	}
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name[:i]))
+	if err != nil {
+		return rpcMethod{}, fmt.Errorf("find service %s: %w", name[:i], err)
+	}
+
+	svc, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return rpcMethod{}, fmt.Errorf("%s is not a service", name[:i])
+	}
+
+	var m rpcMethod
+
+	m.desc = svc.Methods().ByName(protoreflect.Name(name[i+1:]))
+	if m.desc == nil {
+		return rpcMethod{}, fmt.Errorf("method %s not found", fullMethod)
+	}
+
+	m.in, err = protoregistry.GlobalTypes.FindMessageByName(m.desc.Input().FullName())
+	if err != nil {
+		return rpcMethod{}, fmt.Errorf("find request type of %s: %w", fullMethod, err)
+	}
+
+	m.out, err = protoregistry.GlobalTypes.FindMessageByName(m.desc.Output().FullName())
+	if err != nil {
+		return rpcMethod{}, fmt.Errorf("find response type of %s: %w", fullMethod, err)
+	}
+
+	return m, nil
+}
+
+// decodes message of the given type from the recorded JSON.
+func decodeRecordedMessage(typ protoreflect.MessageType, data []byte) (proto.Message, error) {
+	msg := typ.New().Interface()
+
+	err := protojson.Unmarshal(data, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// reads all records of the traffic log file in recording order.
+func readTrafficRecords(fPath string) ([]trafficRecord, error) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+
+	defer f.Close()
+
+	var res []trafficRecord
+
+	r := bufio.NewReader(f)
+
+	for line := 1; ; line++ {
+		data, err := r.ReadBytes('\n')
+		if len(strings.TrimSpace(string(data))) > 0 {
+			var rec trafficRecord
+
+			if errDecode := json.Unmarshal(data, &rec); errDecode != nil {
+				return nil, fmt.Errorf("decode record at line %d: %w", line, errDecode)
+			}
+
+			res = append(res, rec)
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return res, nil
+			}
+
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+	}
+}
+
+// returns message body in canonical JSON form. Signatures and meta information
+// are ignored since they differ between the client runs, the same applies
+// to the given body fields.
+func messageBody(data []byte, ignoredFields ...string) string {
+	var msg struct {
+		Body interface{} `json:"body"`
+	}
+
+	if json.Unmarshal(data, &msg) != nil {
+		return string(data)
+	}
+
+	if body, ok := msg.Body.(map[string]interface{}); ok {
+		for i := range ignoredFields {
+			delete(body, ignoredFields[i])
+		}
+	}
+
+	// maps are encoded with sorted keys
+	res, err := json.Marshal(msg.Body)
+	if err != nil {
+		return string(data)
+	}
+
+	return string(res)
+}
+
+// returns key of the call in the mock by method and bodies of the requests.
+func mockKey(method string, requests []json.RawMessage) string {
+	var sb strings.Builder
+
+	sb.WriteString(method)
+
+	for i := range requests {
+		sb.WriteByte('\n')
+		sb.WriteString(messageBody(requests[i]))
+	}
+
+	return sb.String()
+}
+
+// returns gRPC status code by its string representation.
+func statusCode(s string) codes.Code {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == s {
+			return c
+		}
+	}
+
+	return codes.Unknown
+}
+
+// constructors of the NeoFS API responses by the gRPC message names.
+var signedResponses = map[protoreflect.FullName]func() message.Message{
+	"neo.fs.v2.accounting.BalanceResponse":          func() message.Message { return new(accounting.BalanceResponse) },
+	"neo.fs.v2.container.PutResponse":               func() message.Message { return new(containerV2.PutResponse) },
+	"neo.fs.v2.container.DeleteResponse":            func() message.Message { return new(containerV2.DeleteResponse) },
+	"neo.fs.v2.container.GetResponse":               func() message.Message { return new(containerV2.GetResponse) },
+	"neo.fs.v2.container.ListResponse":              func() message.Message { return new(containerV2.ListResponse) },
+	"neo.fs.v2.container.SetExtendedACLResponse":    func() message.Message { return new(containerV2.SetExtendedACLResponse) },
+	"neo.fs.v2.container.GetExtendedACLResponse":    func() message.Message { return new(containerV2.GetExtendedACLResponse) },
+	"neo.fs.v2.container.AnnounceUsedSpaceResponse": func() message.Message { return new(containerV2.AnnounceUsedSpaceResponse) },
+	"neo.fs.v2.netmap.LocalNodeInfoResponse":        func() message.Message { return new(netmapv2.LocalNodeInfoResponse) },
+	"neo.fs.v2.netmap.NetworkInfoResponse":          func() message.Message { return new(netmapv2.NetworkInfoResponse) },
+	"neo.fs.v2.session.CreateResponse":              func() message.Message { return new(session.CreateResponse) },
+	"neo.fs.v2.object.GetResponse":                  func() message.Message { return new(objectV2.GetResponse) },
+	"neo.fs.v2.object.PutResponse":                  func() message.Message { return new(objectV2.PutResponse) },
+	"neo.fs.v2.object.DeleteResponse":               func() message.Message { return new(objectV2.DeleteResponse) },
+	"neo.fs.v2.object.HeadResponse":                 func() message.Message { return new(objectV2.HeadResponse) },
+	"neo.fs.v2.object.SearchResponse":               func() message.Message { return new(objectV2.SearchResponse) },
+	"neo.fs.v2.object.GetRangeResponse":             func() message.Message { return new(objectV2.GetRangeResponse) },
+	"neo.fs.v2.object.GetRangeHashResponse":         func() message.Message { return new(objectV2.GetRangeHashResponse) },
+}
+
+// replaces verification header of the recorded response with the one signed
+// by the given key since recorded signatures are made by another node.
+func resignResponse(key *ecdsa.PrivateKey, resp proto.Message) (proto.Message, error) {
+	name := resp.ProtoReflect().Descriptor().FullName()
+
+	newResp, ok := signedResponses[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported response type %s", name)
+	}
+
+	msg := newResp()
+
+	err := msg.FromGRPCMessage(resp)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s: %w", name, err)
+	}
+
+	msg.(interface {
+		SetVerificationHeader(*session.ResponseVerificationHeader)
+	}).SetVerificationHeader(nil)
+
+	err = signature.SignServiceMessage(key, msg)
+	if err != nil {
+		return nil, fmt.Errorf("sign %s: %w", name, err)
+	}
+
+	return msg.ToGRPCMessage().(proto.Message), nil
+}
+
+// serves gRPC calls with responses from the recorded traffic. Calls are matched
+// by method and request bodies, unmatched calls are passed to the server.
+type mockTraffic struct {
+	// key to re-sign recorded responses with, responses are served as recorded if nil
+	key *ecdsa.PrivateKey
+
+	mtx sync.Mutex
+	// mock key -> recorded calls, served in recording order, the last one is repeated
+	m map[string][]trafficRecord
+}
+
+// loads recorded calls from the traffic log file.
+func (x *mockTraffic) load(fPath string) error {
+	recs, err := readTrafficRecords(fPath)
+	if err != nil {
+		return err
+	}
+
+	x.m = make(map[string][]trafficRecord, len(recs))
+
+	for i := range recs {
+		key := mockKey(recs[i].Method, recs[i].Requests)
+		x.m[key] = append(x.m[key], recs[i])
+	}
+
+	return nil
+}
+
+// returns next recorded call by the method and requests.
+func (x *mockTraffic) next(method string, requests []json.RawMessage) (trafficRecord, bool) {
+	key := mockKey(method, requests)
+
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	recs := x.m[key]
+	if len(recs) == 0 {
+		return trafficRecord{}, false
+	}
+
+	if len(recs) > 1 {
+		x.m[key] = recs[1:]
+	}
+
+	return recs[0], true
+}
+
+// returns error of the recorded call, nil if call succeeded.
+func recordedError(rec trafficRecord) error {
+	code := statusCode(rec.Status)
+	if code == codes.OK {
+		return nil
+	}
+
+	return status.Error(code, rec.Error)
+}
+
+func (x *mockTraffic) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	rec, ok := x.next(info.FullMethod, appendTrafficMessage(nil, req))
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	if err := recordedError(rec); err != nil {
+		return nil, err
+	}
+
+	if len(rec.Responses) == 0 {
+		return nil, status.Error(codes.Internal, "missing response in the recorded call")
+	}
+
+	m, err := resolveMethod(info.FullMethod)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp, err := x.recordedResponse(m, rec.Responses[0])
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "recorded response: %v", err)
+	}
+
+	return resp, nil
+}
+
+// decodes recorded response and re-signs it with the node key.
+func (x *mockTraffic) recordedResponse(m rpcMethod, data []byte) (proto.Message, error) {
+	resp, err := decodeRecordedMessage(m.out, data)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if x.key == nil {
+		return resp, nil
+	}
+
+	return resignResponse(x.key, resp)
+}
+
+func (x *mockTraffic) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	m, err := resolveMethod(info.FullMethod)
+	if err != nil {
+		return handler(srv, ss)
+	}
+
+	// receive all requests of the client stream or the single one otherwise
+	buffered := &bufferedServerStream{
+		ServerStream: ss,
+	}
+
+	var requests []json.RawMessage
+
+	for {
+		msg := m.in.New().Interface()
+
+		err = ss.RecvMsg(msg)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+
+			buffered.eof = true
+
+			break
+		}
+
+		buffered.msgs = append(buffered.msgs, msg)
+		requests = appendTrafficMessage(requests, msg)
+
+		if !info.IsClientStream {
+			break
+		}
+	}
+
+	rec, ok := x.next(info.FullMethod, requests)
+	if !ok {
+		return handler(srv, buffered)
+	}
+
+	if err = recordedError(rec); err != nil {
+		return err
+	}
+
+	for i := range rec.Responses {
+		resp, err := x.recordedResponse(m, rec.Responses[i])
+		if err != nil {
+			return status.Errorf(codes.Internal, "recorded response #%d: %v", i, err)
+		}
+
+		err = ss.SendMsg(resp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grpc.ServerStream which returns already received messages first.
+type bufferedServerStream struct {
+	grpc.ServerStream
+
+	msgs []proto.Message
+
+	// client has closed the stream after buffered messages
+	eof bool
+}
+
+func (x *bufferedServerStream) RecvMsg(m interface{}) error {
+	if len(x.msgs) > 0 {
+		dst := m.(proto.Message)
+
+		proto.Reset(dst)
+		proto.Merge(dst, x.msgs[0])
+
+		x.msgs = x.msgs[1:]
+
+		return nil
+	}
+
+	if x.eof {
+		return io.EOF
+	}
+
+	return x.ServerStream.RecvMsg(m)
+}
@@ -0,0 +1,217 @@
+package cngl
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-api-go/v2/accounting"
+	accountingapigrpc "github.com/nspcc-dev/neofs-api-go/v2/accounting/grpc"
+	"github.com/nspcc-dev/neofs-api-go/v2/session"
+	sessionapigrpc "github.com/nspcc-dev/neofs-api-go/v2/session/grpc"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serves Balance with the fixed value and Create with random session.
+type testAPIServer struct {
+	balance int64
+
+	// number of the calls reached the server
+	calls int
+}
+
+func (x *testAPIServer) Balance(context.Context, *accountingapigrpc.BalanceRequest) (*accountingapigrpc.BalanceResponse, error) {
+	x.calls++
+
+	var dec accounting.Decimal
+	dec.SetValue(x.balance)
+	dec.SetPrecision(8)
+
+	var body accounting.BalanceResponseBody
+	body.SetBalance(&dec)
+
+	var resp accounting.BalanceResponse
+	resp.SetBody(&body)
+
+	return resp.ToGRPCMessage().(*accountingapigrpc.BalanceResponse), nil
+}
+
+func (x *testAPIServer) Create(context.Context, *sessionapigrpc.CreateRequest) (*sessionapigrpc.CreateResponse, error) {
+	x.calls++
+
+	id := uuid.New()
+
+	var body session.CreateResponseBody
+	body.SetID(id[:])
+	body.SetSessionKey(id[:])
+
+	var resp session.CreateResponse
+	resp.SetBody(&body)
+
+	return resp.ToGRPCMessage().(*sessionapigrpc.CreateResponse), nil
+}
+
+// starts gRPC server with the test services and returns connection to it.
+func startTestAPIServer(t *testing.T, srv *testAPIServer, opts ...grpc.ServerOption) *grpc.ClientConn {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer(opts...)
+
+	accountingapigrpc.RegisterAccountingServiceServer(s, srv)
+	sessionapigrpc.RegisterSessionServiceServer(s, srv)
+
+	go func() { _ = s.Serve(lis) }()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func balanceRequest(ownerID *owner.ID) *accountingapigrpc.BalanceRequest {
+	var req accounting.BalanceRequest
+	req.SetBody(balanceRequestBody(ownerID.ToV2()))
+
+	return req.ToGRPCMessage().(*accountingapigrpc.BalanceRequest)
+}
+
+func createSessionRequest(ownerID *owner.ID) *sessionapigrpc.CreateRequest {
+	var body session.CreateRequestBody
+	body.SetOwnerID(ownerID.ToV2())
+	body.SetExpiration(10)
+
+	var req session.CreateRequest
+	req.SetBody(&body)
+
+	return req.ToGRPCMessage().(*sessionapigrpc.CreateRequest)
+}
+
+// records Balance and Create calls served by the server with the given balance.
+func recordTestTraffic(t *testing.T, ownerID *owner.ID, balance int64) string {
+	l := trafficLog{
+		path:     filepath.Join(t.TempDir(), "traffic.jsonl"),
+		lossless: true,
+	}
+
+	err := l.open(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := startTestAPIServer(t, &testAPIServer{balance: balance},
+		grpc.ChainUnaryInterceptor(l.unaryInterceptor),
+		grpc.ChainStreamInterceptor(l.streamInterceptor),
+	)
+
+	ctx := context.Background()
+
+	_, err = accountingapigrpc.NewAccountingServiceClient(conn).Balance(ctx, balanceRequest(ownerID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sessionapigrpc.NewSessionServiceClient(conn).Create(ctx, createSessionRequest(ownerID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = conn.Close()
+
+	l.close()
+
+	return l.path
+}
+
+func TestTraffic_RecordReplay(t *testing.T) {
+	path := recordTestTraffic(t, ownertest.ID(), 42)
+
+	recs, err := readTrafficRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 recorded calls, got %d", len(recs))
+	}
+
+	// random session ID and key don't affect the comparison
+	conn := startTestAPIServer(t, &testAPIServer{balance: 42})
+
+	for i := range recs {
+		err = replayCall(conn, recs[i], time.Minute)
+		if err != nil {
+			t.Fatalf("replay %s: %v", recs[i].Method, err)
+		}
+	}
+
+	conn = startTestAPIServer(t, &testAPIServer{balance: 43})
+
+	err = replayCall(conn, recs[0], time.Minute)
+	if err == nil {
+		t.Fatal("changed balance is not detected")
+	}
+}
+
+func TestTraffic_Mock(t *testing.T) {
+	ownerID := ownertest.ID()
+
+	path := recordTestTraffic(t, ownerID, 42)
+
+	var mock mockTraffic
+
+	err := mock.load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &testAPIServer{balance: 1}
+
+	conn := startTestAPIServer(t, srv,
+		grpc.ChainUnaryInterceptor(mock.unaryInterceptor),
+		grpc.ChainStreamInterceptor(mock.streamInterceptor),
+	)
+
+	cli := accountingapigrpc.NewAccountingServiceClient(conn)
+
+	// recorded call is served from the record repeatedly
+	for i := 0; i < 2; i++ {
+		resp, err := cli.Balance(context.Background(), balanceRequest(ownerID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v := resp.GetBody().GetBalance().GetValue(); v != 42 {
+			t.Fatalf("expected recorded balance 42, got %d", v)
+		}
+	}
+
+	if srv.calls != 0 {
+		t.Fatal("recorded call reached the server")
+	}
+
+	// unknown call is passed to the server
+	resp, err := cli.Balance(context.Background(), balanceRequest(ownertest.ID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := resp.GetBody().GetBalance().GetValue(); v != 1 || srv.calls != 1 {
+		t.Fatal("unrecorded call is not served by the server")
+	}
+}
@@ -0,0 +1,112 @@
+package cngl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Replay sends calls recorded in the traffic log file to the server over the
+// connection and compares responses with the recorded ones. Handler is called
+// for each recorded call with the mismatch error, nil if the call matches.
+func Replay(conn *grpc.ClientConn, fPath string, timeout time.Duration, handler func(i int, method string, err error)) error {
+	recs, err := readTrafficRecords(fPath)
+	if err != nil {
+		return fmt.Errorf("read recorded calls: %w", err)
+	}
+
+	for i := range recs {
+		handler(i, recs[i].Method, replayCall(conn, recs[i], timeout))
+	}
+
+	return nil
+}
+
+// body fields of the responses which are generated randomly by the server,
+// they are excluded from the comparison.
+var randomResponseFields = map[string][]string{
+	"/neo.fs.v2.session.SessionService/Create": {"id", "sessionKey"},
+}
+
+// sends recorded requests of the call and compares status and response bodies
+// with the recorded ones.
+func replayCall(conn *grpc.ClientConn, rec trafficRecord, timeout time.Duration) error {
+	m, err := resolveMethod(rec.Method)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{
+		ServerStreams: m.desc.IsStreamingServer(),
+		ClientStreams: m.desc.IsStreamingClient(),
+	}, rec.Method)
+	if err != nil {
+		return fmt.Errorf("open stream: %w", err)
+	}
+
+	for i := range rec.Requests {
+		req, err := decodeRecordedMessage(m.in, rec.Requests[i])
+		if err != nil {
+			return fmt.Errorf("decode recorded request #%d: %w", i, err)
+		}
+
+		err = stream.SendMsg(req)
+		if err != nil {
+			// server closed the stream, status is returned from RecvMsg
+			break
+		}
+	}
+
+	err = stream.CloseSend()
+	if err != nil {
+		return fmt.Errorf("close send direction of the stream: %w", err)
+	}
+
+	var responses []json.RawMessage
+
+	for {
+		resp := m.out.New().Interface()
+
+		err = stream.RecvMsg(resp)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			break
+		}
+
+		responses = appendTrafficMessage(responses, resp)
+
+		if !m.desc.IsStreamingServer() {
+			break
+		}
+	}
+
+	if code := status.Code(err).String(); code != rec.Status {
+		return fmt.Errorf("status %s, recorded %s (%v)", code, rec.Status, err)
+	}
+
+	if len(responses) != len(rec.Responses) {
+		return fmt.Errorf("%d responses, recorded %d", len(responses), len(rec.Responses))
+	}
+
+	ignored := randomResponseFields[rec.Method]
+
+	for i := range responses {
+		if got, exp := messageBody(responses[i], ignored...), messageBody(rec.Responses[i], ignored...); got != exp {
+			return fmt.Errorf("body of response #%d differs\ngot:      %s\nrecorded: %s", i, got, exp)
+		}
+	}
+
+	return nil
+}
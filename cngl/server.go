@@ -0,0 +1,392 @@
+package cngl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-node/pkg/services/accounting"
+	"github.com/nspcc-dev/neofs-node/pkg/services/container"
+	svcnetmap "github.com/nspcc-dev/neofs-node/pkg/services/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object"
+	"github.com/nspcc-dev/neofs-node/pkg/services/session"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// size of the in-memory connection buffer.
+const inMemoryBufferSize = 1 << 20
+
+// Server emulates NeoFS network behind the single storage node. It serves NeoFS
+// API over gRPC and admin API over HTTP.
+//
+// Server is created by New and must be stopped by Stop.
+type Server struct {
+	log *zap.Logger
+
+	basics struct {
+		key keys.PrivateKey
+	}
+
+	localNode struct {
+		info netmap.NodeInfo
+	}
+
+	grpc struct {
+		endpoints []grpcEndpoint
+
+		server *grpc.Server
+
+		trafficLog trafficLog
+
+		faults faultInjector
+	}
+
+	admin struct {
+		listenAddress string
+
+		handler http.Handler
+
+		server *http.Server
+	}
+
+	metrics struct {
+		listenAddress string
+
+		state *metrics
+
+		server *http.Server
+	}
+
+	storage struct {
+		path string
+
+		// path is removed on stop
+		temporary bool
+
+		localObjects engine.StorageEngine
+
+		// localObjects is opened and should be closed
+		opened bool
+
+		// epoch events of the local object storage GC
+		gcEvents gcEvents
+
+		sessions sessions
+	}
+
+	fixtures fixtures
+
+	network struct {
+		ir struct {
+			state innerRing
+		}
+
+		netMap struct {
+			state netMap
+
+			ticker epochTicker
+		}
+
+		containers struct {
+			state containers
+		}
+
+		accounting struct {
+			state balances
+		}
+	}
+
+	api struct {
+		object struct {
+			server object.ServiceServer
+		}
+
+		session struct {
+			server session.Server
+		}
+
+		container struct {
+			server container.Server
+		}
+
+		accounting struct {
+			server accounting.Server
+		}
+
+		netmap struct {
+			server svcnetmap.Server
+		}
+	}
+
+	stopOnce sync.Once
+}
+
+// error of the Server preparation. Preparation steps panic with it to abort
+// New, other panics are not recovered.
+type prepareError struct {
+	err error
+}
+
+// aborts Server preparation with the formatted error.
+func failPrepare(format string, args ...interface{}) {
+	panic(prepareError{err: fmt.Errorf(format, args...)})
+}
+
+// New prepares Server with the given options, opens local storage and stores
+// fixture objects. Server doesn't accept connections until one of the Serve
+// methods or Start is called.
+//
+// By default, Server has random key, single-node network map at epoch 1 and
+// temporary local storage.
+func New(opts ...Option) (_ *Server, err error) {
+	s := defaultSettings()
+
+	x := &Server{
+		log: zap.NewNop(),
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			x.release()
+
+			e, ok := r.(prepareError)
+			if !ok {
+				panic(r)
+			}
+
+			err = e.err
+		}
+	}()
+
+	for i := range opts {
+		opts[i](&s)
+	}
+
+	x.prepareLogger(&s)
+
+	x.log.Info("preparing resources...")
+
+	x.prepare(&s)
+
+	x.log.Info("all components are ready")
+
+	x.startLocalObjectStorage()
+	x.storeFixtureObjects()
+	x.network.netMap.ticker.start()
+
+	return x, nil
+}
+
+// Serve accepts gRPC connections on the listener. Blocks until Stop is called
+// or listener fails.
+func (x *Server) Serve(lis net.Listener) error {
+	return x.grpc.server.Serve(lis)
+}
+
+// ServeInMemory serves gRPC in a separate routine over the in-memory listener.
+// Clients connect to the server using DialContext method of the returned
+// listener, e.g. via grpc.WithContextDialer option.
+func (x *Server) ServeInMemory() *bufconn.Listener {
+	lis := bufconn.Listen(inMemoryBufferSize)
+
+	go func() {
+		if err := x.Serve(lis); err != nil {
+			x.log.Error("in-memory gRPC server failure", zap.Error(err))
+		}
+	}()
+
+	return lis
+}
+
+// Start listens gRPC, admin and metrics HTTP endpoints from the settings and
+// serves them in separate routines. Nothing is served if any endpoint can't be
+// listened.
+func (x *Server) Start() error {
+	if len(x.grpc.endpoints) == 0 {
+		return errors.New("missing gRPC listen endpoints")
+	}
+
+	// all endpoints are listened before serving to release them on failure
+	lis := make([]net.Listener, 0, len(x.grpc.endpoints))
+
+	closeListeners := func() {
+		for i := range lis {
+			_ = lis[i].Close()
+		}
+	}
+
+	for _, e := range x.grpc.endpoints {
+		l, err := e.listen()
+		if err != nil {
+			closeListeners()
+			return fmt.Errorf("listen gRPC endpoint %s: %w", e, err)
+		}
+
+		lis = append(lis, l)
+	}
+
+	var lisAdmin, lisMetrics net.Listener
+
+	for _, e := range []struct {
+		name    string
+		address string
+		lis     *net.Listener
+	}{
+		{"admin", x.admin.listenAddress, &lisAdmin},
+		{"metrics", x.metrics.listenAddress, &lisMetrics},
+	} {
+		if e.address == "" {
+			x.log.Info("endpoint is not configured, skip", zap.String("server", e.name))
+			continue
+		}
+
+		l, err := net.Listen("tcp", e.address)
+		if err != nil {
+			closeListeners()
+			return fmt.Errorf("listen %s endpoint: %w", e.name, err)
+		}
+
+		*e.lis = l
+		lis = append(lis, l)
+	}
+
+	for i, e := range x.grpc.endpoints {
+		go func(e grpcEndpoint, lis net.Listener) {
+			x.log.Info("serve gRPC", zap.Stringer("endpoint", e), zap.Bool("tls", e.tls != nil))
+			if err := x.Serve(lis); err != nil {
+				x.log.Error("gRPC server failure", zap.Stringer("endpoint", e), zap.Error(err))
+			}
+		}(e, lis[i])
+	}
+
+	if lisAdmin != nil {
+		x.admin.server = x.serveHTTP("admin", lisAdmin, x.admin.handler)
+	}
+
+	if lisMetrics != nil {
+		x.metrics.server = x.serveHTTP("metrics", lisMetrics, x.metrics.state.handler())
+	}
+
+	return nil
+}
+
+// serves HTTP handler on the listener in a separate routine.
+func (x *Server) serveHTTP(name string, lis net.Listener, h http.Handler) *http.Server {
+	srv := &http.Server{Handler: h}
+
+	go func() {
+		x.log.Info("serve HTTP", zap.String("server", name), zap.Stringer("endpoint", lis.Addr()))
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			x.log.Error("HTTP server failure", zap.String("server", name), zap.Error(err))
+		}
+	}()
+
+	return srv
+}
+
+// Stop stops serving and releases all resources. Temporary local storage is
+// removed.
+func (x *Server) Stop() {
+	x.stopOnce.Do(x.release)
+}
+
+func (x *Server) release() {
+	x.network.netMap.ticker.stop()
+	x.network.containers.state.stop()
+
+	if x.grpc.server != nil {
+		x.grpc.server.GracefulStop()
+	}
+
+	if x.admin.server != nil {
+		_ = x.admin.server.Close()
+	}
+
+	if x.metrics.server != nil {
+		_ = x.metrics.server.Close()
+	}
+
+	x.grpc.trafficLog.close()
+
+	if x.storage.opened {
+		_ = x.storage.localObjects.Close()
+	}
+
+	// stops GC event listener
+	x.storage.gcEvents.stop()
+
+	x.storage.sessions.close()
+
+	if x.storage.temporary {
+		err := os.RemoveAll(x.storage.path)
+		if err != nil {
+			x.log.Error("remove temporary local object storage", zap.Error(err))
+		}
+	}
+
+	_ = x.log.Sync()
+}
+
+// Logger returns logger of the Server.
+func (x *Server) Logger() *zap.Logger {
+	return x.log
+}
+
+// AdminHandler returns handler of the admin HTTP API, e.g. to be served by
+// httptest.Server.
+func (x *Server) AdminHandler() http.Handler {
+	return x.admin.handler
+}
+
+// MetricsHandler returns handler serving Prometheus metrics of the server.
+func (x *Server) MetricsHandler() http.Handler {
+	return x.metrics.state.handler()
+}
+
+// Key returns private key of the local node.
+func (x *Server) Key() *keys.PrivateKey {
+	return &x.basics.key
+}
+
+// CurrentEpoch returns current epoch of the network.
+func (x *Server) CurrentEpoch() uint64 {
+	return x.network.netMap.state.CurrentEpoch()
+}
+
+// TickEpochs advances network epoch by n and returns the new one.
+func (x *Server) TickEpochs(n uint64) uint64 {
+	return x.network.netMap.state.tickEpochs(n)
+}
+
+func (x *Server) startLocalObjectStorage() {
+	err := x.storage.localObjects.Open()
+	if err != nil {
+		failPrepare("open object storage: %w", err)
+	}
+
+	x.storage.opened = true
+
+	err = x.storage.localObjects.Init()
+	if err != nil {
+		failPrepare("init object storage: %w", err)
+	}
+}
+
+func (x *Server) storeFixtureObjects() {
+	err := x.fixtures.storeObjects(&x.storage.localObjects)
+	if err != nil {
+		panic(prepareError{err: err})
+	}
+
+	if len(x.fixtures.objects) > 0 {
+		x.log.Info("fixture objects stored", zap.Int("count", len(x.fixtures.objects)))
+	}
+}
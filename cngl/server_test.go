@@ -0,0 +1,157 @@
+package cngl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	netmapgrpc "github.com/nspcc-dev/neofs-api-go/v2/netmap/grpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, opts ...Option) *Server {
+	srv, err := New(append([]Option{WithLogger(zap.NewNop())}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(srv.Stop)
+
+	return srv
+}
+
+func dialInMemory(t *testing.T, srv *Server) *grpc.ClientConn {
+	lis := srv.ServeInMemory()
+
+	conn, err := grpc.Dial("", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestServer_ServeInMemory(t *testing.T) {
+	srv := newTestServer(t)
+
+	const method = "/neo.fs.v2.netmap.NetmapService/LocalNodeInfo"
+
+	w := httptest.NewRecorder()
+	srv.AdminHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/faults",
+		strings.NewReader(`{"method":"`+method+`","status":"UNAVAILABLE"}`)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("add fault rule: %d %s", w.Code, w.Body)
+	}
+
+	_, err := netmapgrpc.NewNetmapServiceClient(dialInMemory(t, srv)).
+		LocalNodeInfo(context.Background(), new(netmapgrpc.LocalNodeInfoRequest))
+	if code := status.Code(err); code != codes.Unavailable {
+		t.Fatalf("unexpected status %s: %v", code, err)
+	}
+
+	w = httptest.NewRecorder()
+	srv.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	const exp = `neofs_cngl_grpc_calls_total{code="Unavailable",method="` + method + `"} 1`
+	if !strings.Contains(w.Body.String(), exp) {
+		t.Fatalf("missing %s in metrics:\n%s", exp, w.Body)
+	}
+
+	storagePath := srv.storage.path
+
+	srv.Stop()
+
+	if _, err = os.Stat(storagePath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("temporary storage is not removed: %v", err)
+	}
+}
+
+func TestNew_Failure(t *testing.T) {
+	_, err := New(func(s *settings) {
+		s.network.accounting.precision = 10
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported balance precision") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, err = New(WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+	if err == nil {
+		t.Fatal("missing config file is accepted")
+	}
+
+	// unexpected panics are not hidden behind the error
+	defer func() {
+		if r := recover(); r != "unexpected" {
+			t.Fatalf("unexpected panic %v", r)
+		}
+	}()
+
+	_, _ = New(func(*settings) { panic("unexpected") })
+
+	t.Fatal("panic is recovered")
+}
+
+func TestWithConfigFile_Epoch(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, data string) string {
+		fPath := filepath.Join(dir, name)
+
+		err := os.WriteFile(fPath, []byte(data), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return fPath
+	}
+
+	const base = `
+basics:
+  key:
+    path: ""
+local_node:
+  info:
+    path: ""
+storage:
+  path: ""
+network:
+  inner_ring:
+    keys: []
+  netmap:
+`
+
+	withoutEpoch := write("without.yaml", base+"    retention: 5\n")
+	withEpoch := write("with.yaml", base+"    epoch: 7\n")
+
+	for _, tc := range []struct {
+		opts []Option
+		exp  uint64
+	}{
+		{[]Option{WithConfigFile(withoutEpoch)}, 1},
+		{[]Option{WithEpoch(5), WithConfigFile(withoutEpoch)}, 5},
+		{[]Option{WithEpoch(5), WithConfigFile(withEpoch)}, 7},
+		{[]Option{WithConfigFile(withEpoch), WithEpoch(5)}, 5},
+	} {
+		srv := newTestServer(t, tc.opts...)
+
+		if epoch := srv.CurrentEpoch(); epoch != tc.exp {
+			t.Fatalf("unexpected epoch %d instead of %d", epoch, tc.exp)
+		}
+	}
+}
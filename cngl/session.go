@@ -0,0 +1,385 @@
+package cngl
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neofs-api-go/v2/refs"
+	"github.com/nspcc-dev/neofs-api-go/v2/session"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// private part of the session opened by the user.
+type privateSession struct {
+	key *ecdsa.PrivateKey
+
+	// last epoch of the session
+	exp uint64
+}
+
+// storage of the private session keys. Sessions are held in memory and
+// optionally persisted in the BoltDB file encrypted with the node key.
+type sessions struct {
+	log *zap.Logger
+
+	// limit of active sessions per owner, 0 means no limit
+	maxPerOwner uint64
+
+	mtx sync.RWMutex
+	// owner ID + token ID -> session
+	m map[string]privateSession
+	// binary owner ID -> number of active sessions
+	mOwners map[string]uint64
+
+	db *bbolt.DB
+
+	// encrypts persisted sessions, nil if db is nil
+	aead cipher.AEAD
+}
+
+var sessionsBucket = []byte("sessions")
+
+// size of the binary owner ID (NEO3 wallet) which prefixes keys of the sessions.
+const ownerIDSize = 25
+
+func (x *sessions) init() {
+	x.m = make(map[string]privateSession)
+	x.mOwners = make(map[string]uint64)
+}
+
+// returns key of the session in the storage: binary owner ID || token ID.
+func sessionKey(ownerID *owner.ID, tokenID []byte) []byte {
+	bOwner := ownerID.ToV2().GetValue()
+
+	key := make([]byte, 0, len(bOwner)+len(tokenID))
+	key = append(key, bOwner...)
+
+	return append(key, tokenID...)
+}
+
+// returns binary owner ID from the session key.
+func sessionOwner(key string) string {
+	if len(key) < ownerIDSize {
+		return ""
+	}
+
+	return key[:ownerIDSize]
+}
+
+// opens the file of the persistent storage and loads all stored sessions.
+// Sessions are encrypted with the key derived from the given node key.
+func (x *sessions) open(fPath string, nodeKey *keys.PrivateKey) error {
+	encKey := sha256.Sum256(nodeKey.Bytes())
+
+	block, err := aes.NewCipher(encKey[:])
+	if err != nil {
+		return fmt.Errorf("init AES cipher: %w", err)
+	}
+
+	x.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("init AES-GCM: %w", err)
+	}
+
+	x.db, err = bbolt.Open(fPath, 0600, nil)
+	if err != nil {
+		return fmt.Errorf("open BoltDB: %w", err)
+	}
+
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		if err != nil {
+			return fmt.Errorf("create bucket: %w", err)
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			s, err := x.decrypt(v)
+			if err != nil {
+				return fmt.Errorf("decode session: %w", err)
+			}
+
+			x.m[string(k)] = s
+			x.mOwners[sessionOwner(string(k))]++
+
+			return nil
+		})
+	})
+}
+
+func (x *sessions) close() {
+	if x.db != nil {
+		err := x.db.Close()
+		if err != nil {
+			x.log.Error("close session storage", zap.Error(err))
+		}
+	}
+}
+
+// encodes session as nonce || seal(8-byte BE expiration epoch || 32-byte private key).
+func (x *sessions) encrypt(s privateSession) ([]byte, error) {
+	plain := make([]byte, 8, 8+32)
+	binary.BigEndian.PutUint64(plain, s.exp)
+	plain = append(plain, (&keys.PrivateKey{PrivateKey: *s.key}).Bytes()...)
+
+	nonce := make([]byte, x.aead.NonceSize())
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return x.aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (x *sessions) decrypt(data []byte) (privateSession, error) {
+	nonceSz := x.aead.NonceSize()
+	if len(data) < nonceSz {
+		return privateSession{}, errors.New("too short data")
+	}
+
+	plain, err := x.aead.Open(nil, data[:nonceSz], data[nonceSz:], nil)
+	if err != nil {
+		return privateSession{}, fmt.Errorf("decrypt: %w", err)
+	}
+
+	if len(plain) < 8 {
+		return privateSession{}, errors.New("too short plaintext")
+	}
+
+	k, err := keys.NewPrivateKeyFromBytes(plain[8:])
+	if err != nil {
+		return privateSession{}, fmt.Errorf("decode private key: %w", err)
+	}
+
+	return privateSession{
+		key: &k.PrivateKey,
+		exp: binary.BigEndian.Uint64(plain),
+	}, nil
+}
+
+// Create opens new session with random key and ID.
+func (x *sessions) Create(ctx context.Context, body *session.CreateRequestBody) (*session.CreateResponseBody, error) {
+	idOwnerV2 := body.GetOwnerID()
+	if idOwnerV2 == nil {
+		return nil, errors.New("missing owner ID")
+	}
+
+	if len(idOwnerV2.GetValue()) != ownerIDSize {
+		return nil, fmt.Errorf("invalid owner ID length %d", len(idOwnerV2.GetValue()))
+	}
+
+	uid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("generate token ID: %w", err)
+	}
+
+	tokenID, err := uid.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("encode token ID: %w", err)
+	}
+
+	k, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate session key: %w", err)
+	}
+
+	ownerID := owner.NewIDFromV2(idOwnerV2)
+	key := sessionKey(ownerID, tokenID)
+
+	s := privateSession{
+		key: &k.PrivateKey,
+		exp: body.GetExpiration(),
+	}
+
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	strOwner := sessionOwner(string(key))
+
+	if x.maxPerOwner > 0 && x.mOwners[strOwner] >= x.maxPerOwner {
+		return nil, fmt.Errorf("limit of %d active sessions per owner is reached", x.maxPerOwner)
+	}
+
+	if x.db != nil {
+		data, err := x.encrypt(s)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt session: %w", err)
+		}
+
+		err = x.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(sessionsBucket).Put(key, data)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("persist session: %w", err)
+		}
+	}
+
+	x.m[string(key)] = s
+	x.mOwners[strOwner]++
+
+	loggerFromContext(ctx, x.log).Info("session created",
+		zap.Stringer("owner", ownerID),
+		zap.Stringer("id", uid),
+		zap.Uint64("expiration", s.exp),
+	)
+
+	var res session.CreateResponseBody
+
+	res.SetID(tokenID)
+	res.SetSessionKey(k.PublicKey().Bytes())
+
+	return &res, nil
+}
+
+// returns private session of the owner by token ID.
+func (x *sessions) get(ownerID *owner.ID, tokenID []byte) (privateSession, bool) {
+	x.mtx.RLock()
+	s, ok := x.m[string(sessionKey(ownerID, tokenID))]
+	x.mtx.RUnlock()
+
+	return s, ok
+}
+
+// removes sessions which expired at the given epoch or earlier.
+func (x *sessions) removeExpired(epoch uint64) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	var expired [][]byte
+
+	for key, s := range x.m {
+		if s.exp <= epoch {
+			expired = append(expired, []byte(key))
+
+			delete(x.m, key)
+
+			strOwner := sessionOwner(key)
+
+			if x.mOwners[strOwner]--; x.mOwners[strOwner] == 0 {
+				delete(x.mOwners, strOwner)
+			}
+		}
+	}
+
+	if len(expired) == 0 {
+		return
+	}
+
+	x.log.Info("sessions expired", zap.Int("count", len(expired)), zap.Uint64("epoch", epoch))
+
+	if x.db == nil {
+		return
+	}
+
+	err := x.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+
+		for i := range expired {
+			err := b.Delete(expired[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		x.log.Error("remove expired sessions from the storage", zap.Error(err))
+	}
+}
+
+// returns number of active sessions.
+func (x *sessions) count() int {
+	x.mtx.RLock()
+	defer x.mtx.RUnlock()
+
+	return len(x.m)
+}
+
+// public information about the active session.
+type sessionInfo struct {
+	owner *owner.ID
+
+	tokenID []byte
+
+	publicKey *keys.PublicKey
+
+	exp uint64
+}
+
+// returns active sessions of the owner sorted by keys. Returns all sessions if owner is nil.
+func (x *sessions) list(ownerID *owner.ID) []sessionInfo {
+	var prefix string
+
+	if ownerID != nil {
+		prefix = string(ownerID.ToV2().GetValue())
+	}
+
+	x.mtx.RLock()
+	defer x.mtx.RUnlock()
+
+	strKeys := make([]string, 0, len(x.m))
+
+	for key := range x.m {
+		if prefix == "" || sessionOwner(key) == prefix {
+			strKeys = append(strKeys, key)
+		}
+	}
+
+	sort.Strings(strKeys)
+
+	res := make([]sessionInfo, len(strKeys))
+
+	for i, key := range strKeys {
+		var idV2 refs.OwnerID
+		idV2.SetValue([]byte(sessionOwner(key)))
+
+		s := x.m[key]
+
+		res[i] = sessionInfo{
+			owner:     owner.NewIDFromV2(&idV2),
+			tokenID:   []byte(key[ownerIDSize:]),
+			publicKey: (*keys.PublicKey)(&s.key.PublicKey),
+			exp:       s.exp,
+		}
+	}
+
+	return res
+}
+
+// removes all sessions.
+func (x *sessions) reset() error {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.m = make(map[string]privateSession)
+	x.mOwners = make(map[string]uint64)
+
+	if x.db == nil {
+		return nil
+	}
+
+	return x.db.Update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket(sessionsBucket)
+		if err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+
+		_, err = tx.CreateBucket(sessionsBucket)
+
+		return err
+	})
+}
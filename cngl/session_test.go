@@ -0,0 +1,169 @@
+package cngl
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neofs-api-go/v2/refs"
+	"github.com/nspcc-dev/neofs-api-go/v2/session"
+	"github.com/nspcc-dev/neofs-sdk-go/owner"
+	ownertest "github.com/nspcc-dev/neofs-sdk-go/owner/test"
+	"go.uber.org/zap"
+)
+
+func TestSessions_Persistence(t *testing.T) {
+	fPath := filepath.Join(t.TempDir(), "sessions.db")
+
+	nodeKey, err := keys.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open := func(key *keys.PrivateKey) (*sessions, error) {
+		s := &sessions{log: zap.NewNop()}
+		s.init()
+
+		return s, s.open(fPath, key)
+	}
+
+	ownerID := ownertest.ID()
+
+	var body session.CreateRequestBody
+	body.SetOwnerID(ownerID.ToV2())
+	body.SetExpiration(10)
+
+	s, err := open(nodeKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Create(context.Background(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, _ := s.get(ownerID, res.GetID())
+
+	s.close()
+
+	otherKey, err := keys.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = open(otherKey)
+	s.close()
+
+	if err == nil {
+		t.Fatal("sessions are decrypted with another key")
+	}
+
+	s, err = open(nodeKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.close()
+
+	loaded, ok := s.get(ownerID, res.GetID())
+	if !ok {
+		t.Fatal("session is not loaded from the file")
+	}
+
+	if loaded.exp != 10 || !loaded.key.Equal(created.key) {
+		t.Fatal("loaded session differs from the created one")
+	}
+}
+
+func TestSessions_ExpirationAndLimit(t *testing.T) {
+	s := &sessions{log: zap.NewNop()}
+	s.init()
+	s.maxPerOwner = 2
+
+	owner1 := ownertest.ID()
+	owner2 := ownertest.ID()
+
+	create := func(ownerID *owner.ID, exp uint64) error {
+		var body session.CreateRequestBody
+		body.SetOwnerID(ownerID.ToV2())
+		body.SetExpiration(exp)
+
+		_, err := s.Create(context.Background(), &body)
+
+		return err
+	}
+
+	for _, exp := range []uint64{10, 20} {
+		if err := create(owner1, exp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := create(owner1, 30); err == nil {
+		t.Fatal("limit of sessions per owner is exceeded")
+	}
+
+	if err := create(owner2, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	if l := s.list(nil); len(l) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(l))
+	}
+
+	l := s.list(owner1)
+	if len(l) != 2 {
+		t.Fatalf("expected 2 sessions of the owner, got %d", len(l))
+	}
+
+	if bytes.Compare(l[0].tokenID, l[1].tokenID) >= 0 {
+		t.Fatal("sessions are not sorted")
+	}
+
+	for i := range l {
+		if !l[i].owner.Equal(owner1) {
+			t.Fatal("session of another owner is listed")
+		}
+	}
+
+	s.removeExpired(10)
+
+	l = s.list(owner1)
+	if len(l) != 1 || l[0].exp != 20 {
+		t.Fatal("expired session is not removed")
+	}
+
+	if err := create(owner1, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	s.removeExpired(30)
+
+	if l := s.list(nil); len(l) != 0 {
+		t.Fatalf("expected no sessions, got %d", len(l))
+	}
+}
+
+func TestSessions_InvalidOwner(t *testing.T) {
+	s := &sessions{log: zap.NewNop()}
+	s.init()
+
+	var idOwner refs.OwnerID
+	idOwner.SetValue([]byte("short"))
+
+	var body session.CreateRequestBody
+	body.SetOwnerID(&idOwner)
+
+	_, err := s.Create(context.Background(), &body)
+	if err == nil || !strings.Contains(err.Error(), "invalid owner ID length") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(s.list(nil)) != 0 {
+		t.Fatal("session of the invalid owner is created")
+	}
+}
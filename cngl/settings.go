@@ -0,0 +1,277 @@
+package cngl
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neofs-sdk-go/netmap"
+	"go.uber.org/zap"
+)
+
+// description of the virtual storage node in the config.
+type cfgNode struct {
+	// hex-encoded public key
+	key string
+
+	addresses []string
+
+	// attributes in KEY:VALUE format
+	attributes []string
+}
+
+// scheduled change of the node state in the config.
+type cfgNodeEvent struct {
+	epoch uint64
+
+	// hex-encoded public key
+	key string
+
+	state string
+}
+
+// initialization parameters of the Server. Filled from the config file and
+// options in the order they are passed to New.
+type settings struct {
+	logger struct {
+		// overrides cfg
+		log *zap.Logger
+
+		cfg cfgLogger
+	}
+
+	basics struct {
+		// overrides keyFilepath, random key is generated if both are missing
+		key *keys.PrivateKey
+
+		keyFilepath string
+	}
+
+	localNode struct {
+		// overrides infoFilepath
+		info *netmap.NodeInfo
+
+		infoFilepath string
+	}
+
+	grpc struct {
+		endpoints []cfgEndpoint
+
+		dump struct {
+			enabled bool
+
+			filters []string
+		}
+
+		trafficLog struct {
+			path string
+
+			maxSize int64
+
+			maxBackups int
+
+			buffer int
+
+			lossless bool
+		}
+
+		mockFilepath string
+
+		faults struct {
+			seed int64
+
+			rules []cfgFaultRule
+		}
+	}
+
+	admin struct {
+		listenAddress string
+	}
+
+	metrics struct {
+		listenAddress string
+	}
+
+	network struct {
+		ir struct {
+			keysStr []string
+		}
+
+		netMap struct {
+			epoch uint64
+
+			autoTick bool
+
+			retention uint64
+
+			nodes []cfgNode
+
+			// in addition to nodes and nodesFilepath
+			nodeInfos []netmap.NodeInfo
+
+			nodesFilepath string
+
+			events []cfgNodeEvent
+		}
+
+		parameters networkParameters
+
+		accounting struct {
+			precision uint32
+
+			defaultBalance int64
+
+			// balances in OWNER=AMOUNT format
+			balances []string
+
+			ledgerFilepath string
+		}
+
+		containers struct {
+			latencyBlocks uint64
+
+			latencyDuration time.Duration
+
+			lenientPlacement bool
+		}
+	}
+
+	storage struct {
+		// temporary directory is used if empty
+		localObjectsFilepath string
+
+		sessionsFilepath string
+
+		maxSessionsPerOwner uint64
+	}
+
+	fixtures struct {
+		containersFilepaths []string
+
+		eACLFilepaths []string
+
+		objectsFilepaths []string
+	}
+}
+
+// returns settings with default values of the optional parameters.
+func defaultSettings() settings {
+	var s settings
+
+	s.logger.cfg.level = "info"
+	s.logger.cfg.format = "console"
+	s.logger.cfg.output = "stderr"
+	s.network.netMap.epoch = 1
+	s.network.accounting.precision = balancePrecisionGAS
+
+	prm := &s.network.parameters
+	prm.magic = defaultNetworkMagic
+	prm.msPerBlock = defaultMsPerBlock
+	prm.epochDuration = defaultEpochDuration
+	prm.maxObjectSize = defaultMaxObjectSize
+	prm.eigenTrustIterations = defaultEigenTrustIterations
+	prm.eigenTrustAlpha = defaultEigenTrustAlpha
+
+	return s
+}
+
+// Option configures the Server.
+type Option func(*settings)
+
+// WithConfigFile reads parameters from the JSON or YAML config file. Options
+// passed after it override the values from the file.
+func WithConfigFile(fPath string) Option {
+	return func(s *settings) {
+		s.readFile(fPath)
+	}
+}
+
+// WithLogger sets logger of the Server and all its components. By default,
+// logger writes info messages to stderr in console format.
+func WithLogger(l *zap.Logger) Option {
+	return func(s *settings) {
+		s.logger.log = l
+	}
+}
+
+// WithKey sets private key of the local node. Random key is generated by
+// default.
+func WithKey(key *keys.PrivateKey) Option {
+	return func(s *settings) {
+		s.basics.key = key
+	}
+}
+
+// WithLocalNodeInfo sets information about the local node. Missing state and
+// public key are set to online and local node key respectively.
+func WithLocalNodeInfo(info netmap.NodeInfo) Option {
+	return func(s *settings) {
+		s.localNode.info = &info
+	}
+}
+
+// WithNetMapNodes adds virtual storage nodes to the network map.
+func WithNetMapNodes(nodes ...netmap.NodeInfo) Option {
+	return func(s *settings) {
+		s.network.netMap.nodeInfos = append(s.network.netMap.nodeInfos, nodes...)
+	}
+}
+
+// WithEpoch sets initial epoch of the network. Defaults to 1.
+func WithEpoch(epoch uint64) Option {
+	return func(s *settings) {
+		s.network.netMap.epoch = epoch
+	}
+}
+
+// WithStoragePath sets path to the directory of the local object storage.
+func WithStoragePath(dir string) Option {
+	return func(s *settings) {
+		s.storage.localObjectsFilepath = dir
+	}
+}
+
+// WithTemporaryStorage stores objects on disk in the new directory created
+// in os.TempDir, the directory is removed on Stop. Objects are not kept in
+// memory. Used by default.
+func WithTemporaryStorage() Option {
+	return WithStoragePath("")
+}
+
+// WithGRPCEndpoint adds plain gRPC endpoint listened in Start. Address is
+// host:port or unix:<socket path>. Endpoints are announced in the local node
+// addresses.
+func WithGRPCEndpoint(addr string) Option {
+	return func(s *settings) {
+		s.grpc.endpoints = append(s.grpc.endpoints, cfgEndpoint{
+			address: addr,
+		})
+	}
+}
+
+// WithGRPCTLSEndpoint is the same as WithGRPCEndpoint, but serves gRPC over TLS
+// with the given config.
+func WithGRPCTLSEndpoint(addr string, cfg *tls.Config) Option {
+	return func(s *settings) {
+		s.grpc.endpoints = append(s.grpc.endpoints, cfgEndpoint{
+			address:   addr,
+			tlsConfig: cfg,
+		})
+	}
+}
+
+// WithMetricsEndpoint sets address to serve Prometheus metrics on in Start.
+// Metrics are not served if address is empty.
+func WithMetricsEndpoint(addr string) Option {
+	return func(s *settings) {
+		s.metrics.listenAddress = addr
+	}
+}
+
+// WithAdminEndpoint sets address to listen admin HTTP on in Start. Admin
+// server is not started if address is empty.
+func WithAdminEndpoint(addr string) Option {
+	return func(s *settings) {
+		s.admin.listenAddress = addr
+	}
+}
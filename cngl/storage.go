@@ -0,0 +1,119 @@
+package cngl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/shard"
+)
+
+var errStorageWipe = errors.New("local object storage is being wiped")
+
+// subdirectories of the local object storage shard.
+const (
+	storageBlobDir = "blob"
+	storageMetaDir = "meta"
+)
+
+// removes all objects from the local storage located in the given directory.
+// Storage is closed, its files are removed, then it's opened and initialized
+// again, so the objects can be stored again without any traces of the previous
+// ones (e.g. graves). Other files in the directory are kept.
+func wipeLocalObjects(e *engine.StorageEngine, dir string, events *gcEvents) error {
+	err := e.BlockExecution(errStorageWipe)
+	if err != nil {
+		return fmt.Errorf("block storage: %w", err)
+	}
+
+	// stop listener of the closed GC, Init starts the new one
+	events.close()
+
+	for _, sub := range []string{storageBlobDir, storageMetaDir} {
+		err = os.RemoveAll(filepath.Join(dir, sub))
+		if err != nil {
+			return fmt.Errorf("remove storage files: %w", err)
+		}
+	}
+
+	err = e.ResumeExecution()
+	if err != nil {
+		return fmt.Errorf("reopen storage: %w", err)
+	}
+
+	err = e.Init()
+	if err != nil {
+		return fmt.Errorf("init storage: %w", err)
+	}
+
+	return nil
+}
+
+// forwards new epochs to the GC of the local object storage. Each shard
+// initialization starts GC listening the new channel, so the channel is
+// recreated in init and closed when the storage is closed.
+type gcEvents struct {
+	mtx sync.Mutex
+
+	// nil if closed
+	ch chan shard.Event
+
+	// storage is closed for good
+	stopped bool
+}
+
+// returns new event channel, previous one is closed. Returns closed channel
+// after stop, so the listener exits immediately.
+func (x *gcEvents) init() <-chan shard.Event {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.closeChannel()
+
+	ch := make(chan shard.Event)
+
+	if x.stopped {
+		close(ch)
+	} else {
+		x.ch = ch
+	}
+
+	return ch
+}
+
+// must be called under mtx.
+func (x *gcEvents) closeChannel() {
+	if x.ch != nil {
+		close(x.ch)
+		x.ch = nil
+	}
+}
+
+// closes current event channel. Events are dropped until next init.
+func (x *gcEvents) close() {
+	x.mtx.Lock()
+	x.closeChannel()
+	x.mtx.Unlock()
+}
+
+// closes current event channel and makes the next ones closed. Events are
+// dropped after the call.
+func (x *gcEvents) stop() {
+	x.mtx.Lock()
+	x.stopped = true
+	x.closeChannel()
+	x.mtx.Unlock()
+}
+
+// sends new epoch event to the GC, does nothing if channel is closed.
+func (x *gcEvents) newEpoch(epoch uint64) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	if x.ch != nil {
+		x.ch <- shard.EventNewEpoch(epoch)
+	}
+}
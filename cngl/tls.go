@@ -0,0 +1,145 @@
+package cngl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+// TLS parameters of the gRPC listener.
+type cfgTLS struct {
+	enabled bool
+
+	// PEM-encoded certificate and private key, certificate is written here
+	// if selfSigned is set
+	certFilepath string
+
+	keyFilepath string
+
+	// PEM-encoded CA certificates to verify client certificates, clients
+	// are not required to present certificates if empty
+	clientCAFilepath string
+
+	// generate certificate on startup
+	selfSigned bool
+}
+
+// validity period of the self-signed certificate.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// builds TLS config of the gRPC listener. Self-signed certificate is issued
+// for the host of the listen address and localhost.
+func (x cfgTLS) toConfig(listenAddress string) (*tls.Config, error) {
+	var (
+		cert tls.Certificate
+		err  error
+	)
+
+	if x.selfSigned {
+		cert, err = selfSignedCertificate(listenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("generate self-signed certificate: %w", err)
+		}
+
+		if x.certFilepath != "" {
+			err = os.WriteFile(x.certFilepath, pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: cert.Certificate[0],
+			}), 0644)
+			if err != nil {
+				return nil, fmt.Errorf("write self-signed certificate: %w", err)
+			}
+		}
+	} else {
+		if x.certFilepath == "" || x.keyFilepath == "" {
+			return nil, errors.New("missing certificate or key file")
+		}
+
+		cert, err = tls.LoadX509KeyPair(x.certFilepath, x.keyFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("load certificate: %w", err)
+		}
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		// gRPC clients negotiate HTTP/2
+		NextProtos: []string{"h2"},
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if x.clientCAFilepath != "" {
+		pemCA, err := os.ReadFile(x.clientCAFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %w", err)
+		}
+
+		cfg.ClientCAs = x509.NewCertPool()
+
+		if !cfg.ClientCAs.AppendCertsFromPEM(pemCA) {
+			return nil, errors.New("no certificates in the client CA file")
+		}
+
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// issues self-signed certificate for the host of the address and localhost.
+func selfSignedCertificate(address string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generate key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generate serial number: %w", err)
+	}
+
+	now := time.Now()
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "neofs-cngl"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(selfSignedValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	if host, _, err := net.SplitHostPort(address); err == nil && host != "" {
+		if ip := net.ParseIP(host); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else if host != "localhost" {
+			tmpl.DNSNames = append(tmpl.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("create certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
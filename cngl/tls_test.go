@@ -0,0 +1,97 @@
+package cngl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func freeLocalAddress(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := lis.Addr().String()
+
+	if err = lis.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return addr
+}
+
+// calls method unknown to the server: Unimplemented status proves that
+// request reached the server.
+func callUnknownMethod(conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return conn.Invoke(ctx, "/test.Unknown/Method", new(emptypb.Empty), new(emptypb.Empty))
+}
+
+func TestServer_SelfSignedTLS(t *testing.T) {
+	addr := freeLocalAddress(t)
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+
+	srv := newTestServer(t, func(s *settings) {
+		s.grpc.endpoints = append(s.grpc.endpoints, cfgEndpoint{
+			address: addr,
+			tls: cfgTLS{
+				enabled:      true,
+				certFilepath: certFile,
+				selfSigned:   true,
+			},
+		})
+	})
+
+	err := srv.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pemCert, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pemCert) {
+		t.Fatal("no certificate in the written file")
+	}
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: roots,
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	if code := status.Code(callUnknownMethod(conn)); code != codes.Unimplemented {
+		t.Fatalf("unexpected status over TLS %s", code)
+	}
+
+	plainConn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = plainConn.Close() })
+
+	if code := status.Code(callUnknownMethod(plainConn)); code != codes.Unavailable {
+		t.Fatalf("plain connection is served with status %s", code)
+	}
+}
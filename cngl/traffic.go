@@ -0,0 +1,290 @@
+package cngl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// single gRPC call in the traffic log.
+type trafficRecord struct {
+	Time time.Time `json:"time"`
+
+	Method string `json:"method"`
+
+	Peer string `json:"peer,omitempty"`
+
+	// in nanoseconds
+	Duration time.Duration `json:"duration"`
+
+	Status string `json:"status"`
+
+	Error string `json:"error,omitempty"`
+
+	// messages received from the client, exactly one for unary calls
+	Requests []json.RawMessage `json:"requests"`
+
+	// messages sent to the client, exactly one for successful unary calls
+	Responses []json.RawMessage `json:"responses"`
+}
+
+// writes gRPC calls to the file in JSON Lines format. Records are written
+// asynchronously, file is rotated when its size exceeds the limit.
+type trafficLog struct {
+	log *zap.Logger
+
+	path string
+
+	// file size limit in bytes, 0 means no rotation
+	maxSize int64
+
+	// number of rotated files to keep
+	maxBackups int
+
+	// block callers when buffer is full instead of dropping the records,
+	// required to record complete sessions
+	lossless bool
+
+	ch chan *trafficRecord
+
+	// records dropped due to full buffer
+	dropped uint64
+
+	wg sync.WaitGroup
+
+	f *os.File
+
+	size int64
+}
+
+// default number of records buffered for writing.
+const defaultTrafficLogBuffer = 1024
+
+var trafficLogJSONOpts = protojson.MarshalOptions{
+	EmitUnpopulated: true,
+}
+
+// opens the log file and starts writing routine.
+func (x *trafficLog) open(buffer int) error {
+	err := x.openFile()
+	if err != nil {
+		return err
+	}
+
+	if buffer <= 0 {
+		buffer = defaultTrafficLogBuffer
+	}
+
+	x.ch = make(chan *trafficRecord, buffer)
+
+	x.wg.Add(1)
+
+	go func() {
+		defer x.wg.Done()
+
+		for rec := range x.ch {
+			x.write(rec)
+		}
+	}()
+
+	return nil
+}
+
+func (x *trafficLog) openFile() error {
+	f, err := os.OpenFile(x.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open traffic log file: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stat traffic log file: %w", err)
+	}
+
+	x.f = f
+	x.size = fi.Size()
+
+	return nil
+}
+
+// writes buffered records and closes the file. Does nothing if log wasn't opened.
+func (x *trafficLog) close() {
+	if x.ch == nil {
+		return
+	}
+
+	close(x.ch)
+	x.wg.Wait()
+
+	if n := atomic.LoadUint64(&x.dropped); n > 0 {
+		x.log.Warn("records were dropped from the traffic log due to full buffer", zap.Uint64("count", n))
+	}
+
+	err := x.f.Close()
+	if err != nil {
+		x.log.Error("close traffic log file", zap.Error(err))
+	}
+}
+
+// queues the record for writing, drops it if buffer is full in lossy mode.
+func (x *trafficLog) push(rec *trafficRecord) {
+	if x.lossless {
+		x.ch <- rec
+		return
+	}
+
+	select {
+	case x.ch <- rec:
+	default:
+		atomic.AddUint64(&x.dropped, 1)
+	}
+}
+
+func (x *trafficLog) write(rec *trafficRecord) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		x.log.Error("encode traffic log record", zap.Error(err))
+		return
+	}
+
+	data = append(data, '\n')
+
+	if x.maxSize > 0 && x.size > 0 && x.size+int64(len(data)) > x.maxSize {
+		err = x.rotate()
+		if err != nil {
+			x.log.Error("rotate traffic log", zap.Error(err))
+		}
+	}
+
+	n, err := x.f.Write(data)
+	x.size += int64(n)
+
+	if err != nil {
+		x.log.Error("write traffic log record", zap.Error(err))
+	}
+}
+
+// shifts rotated files (path.1 -> path.2, etc.), moves current file to path.1
+// and opens new one. Files beyond the backup limit are removed.
+func (x *trafficLog) rotate() error {
+	err := x.f.Close()
+	if err != nil {
+		return fmt.Errorf("close traffic log file: %w", err)
+	}
+
+	if x.maxBackups > 0 {
+		for i := x.maxBackups - 1; i > 0; i-- {
+			_ = os.Rename(x.path+"."+strconv.Itoa(i), x.path+"."+strconv.Itoa(i+1))
+		}
+
+		err = os.Rename(x.path, x.path+".1")
+	} else {
+		err = os.Remove(x.path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("move traffic log file: %w", err)
+	}
+
+	return x.openFile()
+}
+
+// appends JSON of the message to dst. Messages which can't be encoded
+// are written as JSON strings with the error.
+func appendTrafficMessage(dst []json.RawMessage, msg interface{}) []json.RawMessage {
+	data, err := encodeMessage(trafficLogJSONOpts, msg)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("encode message: %v", err))
+	}
+
+	return append(dst, data)
+}
+
+// completes the record of the finished call and queues it for writing.
+func (x *trafficLog) finish(ctx context.Context, rec *trafficRecord, err error) {
+	rec.Duration = time.Since(rec.Time)
+	rec.Status = status.Code(err).String()
+
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		rec.Peer = p.Addr.String()
+	}
+
+	x.push(rec)
+}
+
+func (x *trafficLog) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	rec := &trafficRecord{
+		Time:   time.Now(),
+		Method: info.FullMethod,
+	}
+
+	rec.Requests = appendTrafficMessage(rec.Requests, req)
+
+	resp, err := handler(ctx, req)
+	if err == nil {
+		rec.Responses = appendTrafficMessage(rec.Responses, resp)
+	}
+
+	x.finish(ctx, rec, err)
+
+	return resp, err
+}
+
+func (x *trafficLog) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	s := &trafficServerStream{
+		ServerStream: ss,
+		rec: &trafficRecord{
+			Time:   time.Now(),
+			Method: info.FullMethod,
+		},
+	}
+
+	err := handler(srv, s)
+
+	x.finish(ss.Context(), s.rec, err)
+
+	return err
+}
+
+// grpc.ServerStream which records all received and sent messages.
+type trafficServerStream struct {
+	grpc.ServerStream
+
+	mtx sync.Mutex
+	rec *trafficRecord
+}
+
+func (x *trafficServerStream) RecvMsg(m interface{}) error {
+	err := x.ServerStream.RecvMsg(m)
+	if err == nil {
+		x.mtx.Lock()
+		x.rec.Requests = appendTrafficMessage(x.rec.Requests, m)
+		x.mtx.Unlock()
+	}
+
+	return err
+}
+
+func (x *trafficServerStream) SendMsg(m interface{}) error {
+	x.mtx.Lock()
+	x.rec.Responses = appendTrafficMessage(x.rec.Responses, m)
+	x.mtx.Unlock()
+
+	return x.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,122 @@
+package cngl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// reads records of the traffic log file.
+func readTrafficLog(t *testing.T, path string) []trafficRecord {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	var res []trafficRecord
+
+	s := bufio.NewScanner(f)
+
+	for s.Scan() {
+		var rec trafficRecord
+
+		err = json.Unmarshal(s.Bytes(), &rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res = append(res, rec)
+	}
+
+	if err = s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestTrafficLog_Rotation(t *testing.T) {
+	l := trafficLog{
+		path:       filepath.Join(t.TempDir(), "traffic.jsonl"),
+		maxSize:    1, // rotate before each record
+		maxBackups: 2,
+	}
+
+	err := l.open(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 5
+
+	for i := 0; i < n; i++ {
+		_, err = l.unaryInterceptor(context.Background(), wrapperspb.String("request"),
+			&grpc.UnaryServerInfo{FullMethod: "/test.Service/Method" + strconv.Itoa(i)},
+			func(context.Context, interface{}) (interface{}, error) {
+				return wrapperspb.String("response"), nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l.close()
+
+	// current file keeps the last record, backups keep the previous ones
+	for i, suffix := range []string{"", ".1", ".2"} {
+		recs := readTrafficLog(t, l.path+suffix)
+		if len(recs) != 1 {
+			t.Fatalf("expected 1 record in %s, got %d", suffix, len(recs))
+		}
+
+		rec := recs[0]
+
+		if exp := "/test.Service/Method" + strconv.Itoa(n-1-i); rec.Method != exp {
+			t.Fatalf("unexpected method in %s: %s instead of %s", suffix, rec.Method, exp)
+		}
+
+		if rec.Status != "OK" || len(rec.Requests) != 1 || len(rec.Responses) != 1 {
+			t.Fatalf("unexpected record in %s: %+v", suffix, rec)
+		}
+	}
+
+	if _, err = os.Stat(l.path + ".3"); !os.IsNotExist(err) {
+		t.Fatal("backup beyond the limit is kept")
+	}
+}
+
+func TestTrafficLog_NoBackups(t *testing.T) {
+	l := trafficLog{
+		path:    filepath.Join(t.TempDir(), "traffic.jsonl"),
+		maxSize: 1,
+	}
+
+	err := l.open(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		l.push(&trafficRecord{Method: strconv.Itoa(i)})
+	}
+
+	l.close()
+
+	recs := readTrafficLog(t, l.path)
+	if len(recs) != 1 || recs[0].Method != "2" {
+		t.Fatalf("unexpected records %+v", recs)
+	}
+
+	if _, err = os.Stat(l.path + ".1"); !os.IsNotExist(err) {
+		t.Fatal("backup is kept without backups enabled")
+	}
+}
@@ -1,6 +1,52 @@
 package main
 
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/cthulhu-rider/neofs-cngl/cngl"
+	"go.uber.org/zap"
+)
+
 func main() {
-	var a app
-	a.start()
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		runReplay(os.Args[2:])
+		return
+	}
+
+	fPath := flag.String("config", "", "Filepath to JSON or YAML config file")
+
+	flag.Parse()
+
+	if *fPath == "" {
+		log.Fatal("missing config filepath")
+	}
+
+	srv, err := cngl.New(cngl.WithConfigFile(*fPath))
+	if err != nil {
+		log.Fatalf("prepare application: %v", err)
+	}
+
+	defer srv.Stop()
+
+	l := srv.Logger()
+
+	err = srv.Start()
+	if err != nil {
+		l.Error("start application", zap.Error(err))
+		srv.Stop()
+		os.Exit(1)
+	}
+
+	l.Info("application started, waiting for OS signal...")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	<-ctx.Done()
+
+	l.Info("interrupt application on OS signal")
 }
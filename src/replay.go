@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cthulhu-rider/neofs-cngl/cngl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// runs replay command: sends calls recorded in the traffic log to the server
+// and compares responses with the recorded ones.
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+
+	target := fs.String("target", "localhost:8091", "gRPC endpoint of the server to replay calls against")
+	timeout := fs.Duration("timeout", 30*time.Second, "Timeout of the single call")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s replay [flags] <traffic log file>\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	conn, err := grpc.Dial(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("connect to %s: %v", *target, err)
+	}
+
+	defer conn.Close()
+
+	var failed, total int
+
+	err = cngl.Replay(conn, fs.Arg(0), *timeout, func(i int, method string, err error) {
+		total++
+
+		if err != nil {
+			failed++
+
+			log.Printf("call #%d %s: MISMATCH: %v\n", i, method, err)
+		} else {
+			log.Printf("call #%d %s: OK\n", i, method)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("%d of %d calls replayed with mismatches\n", failed, total)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}